@@ -14,6 +14,8 @@ import (
 	"sort"
 
 	"golang.org/x/exp/constraints"
+
+	"github.com/udugong/ukit/iterx"
 )
 
 // The Interface type describes the requirements
@@ -182,3 +184,16 @@ func (h *Heap[T]) Replace(i int, x T) {
 	(*h)[i] = x
 	h.Fix(i)
 }
+
+// All 按照从小到大的顺序迭代堆中的元素, 不会修改堆.
+// 迭代时会复制一份堆, 每次产生一个元素的时间复杂度为 O(log n).
+func (h *Heap[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		c := append(Heap[T](nil), *h...)
+		for c.Len() > 0 {
+			if !yield(Pop[T](&c)) {
+				return
+			}
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/constraints"
+
+	"github.com/udugong/ukit/iterx"
 )
 
 func (h Heap[T]) verify(t *testing.T, i int) {
@@ -244,3 +246,14 @@ func TestHeap_Replace(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func TestHeap_All(t *testing.T) {
+	h := NewHeap(0, 5, 3, 8, 1, 4)
+	want := []int{1, 3, 4, 5, 8}
+	assert.Equal(t, want, iterx.Collect(h.All()))
+	assert.Equal(t, want[:2], iterx.Collect(iterx.Take(h.All(), 2)))
+	// 迭代不会修改堆
+	assert.Equal(t, 5, h.Len())
+	assert.Equal(t, 1, (*h)[0])
+	assert.Equal(t, []int{}, iterx.Collect(NewHeap[int](0).All()))
+}
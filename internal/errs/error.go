@@ -6,3 +6,18 @@ import "fmt"
 func NewErrIndexOutOfRange(length int, index int) error {
 	return fmt.Errorf("ukit: 下标超出范围, 长度 %d, 下标 %d", length, index)
 }
+
+// NewErrInvalidType 创建一个代表元素类型不匹配的错误.
+func NewErrInvalidType(index int, want string, got any) error {
+	return fmt.Errorf("ukit: 下标 %d 的元素类型不匹配, 期望 %s, 实际 %T", index, want, got)
+}
+
+// NewErrOverflow 创建一个代表数值超出目标类型范围的错误.
+func NewErrOverflow(index int, value any, typ string) error {
+	return fmt.Errorf("ukit: 下标 %d 的值 %v 超出了 %s 的范围", index, value, typ)
+}
+
+// NewErrInvalidElement 创建一个代表元素不合法的错误, err 为具体的原因.
+func NewErrInvalidElement(index int, err error) error {
+	return fmt.Errorf("ukit: 下标 %d 的元素不合法: %w", index, err)
+}
@@ -0,0 +1,42 @@
+package errs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrGoexit 代表用户传入的函数调用了 runtime.Goexit.
+var ErrGoexit = errors.New("ukit: 函数调用了 runtime.Goexit")
+
+// PanicError 从 panic 中恢复的值以及执行函数时的堆栈信息.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error 实现 error 接口.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("%v\n\n%s", p.Value, p.Stack)
+}
+
+// Unwrap 如果 panic 的值是 error 则返回它.
+func (p *PanicError) Unwrap() error {
+	err, ok := p.Value.(error)
+	if !ok {
+		return nil
+	}
+	return err
+}
+
+// NewPanicError 创建一个包含当前堆栈信息的 PanicError, 需要在 recover 所在的 defer 中调用.
+func NewPanicError(v any) error {
+	stack := debug.Stack()
+	// 堆栈的第一行为 "goroutine N [status]:", 但是错误被处理时该 goroutine
+	// 可能已经不存在或者状态已经改变, 所以去掉这一行以免误导
+	if line := bytes.IndexByte(stack, '\n'); line >= 0 {
+		stack = stack[line+1:]
+	}
+	return &PanicError{Value: v, Stack: stack}
+}
@@ -1,6 +1,7 @@
 package slice
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -304,3 +305,113 @@ func BenchmarkDelete(b *testing.B) {
 		}
 	})
 }
+
+type elem8 [1]int64
+
+type elem64 [8]int64
+
+type elem512 [64]int64
+
+// BenchmarkDeleteStrategy 对比不同元素大小和删除位置下各个删除策略的性能.
+// 每次删除后重新切片恢复长度, 计时中不包含准备数据的开销.
+// DeleteByAppend 和 DeleteByCopy 底层都使用 memmove, 两者在所有情况下基本持平.
+// DeleteByIter 逐个赋值, 只在删除末尾元素 (不需要移动) 时快 1~2ns,
+// 或者在元素很小时与 memmove 互有胜负; 需要移动的字节越多越慢, 最多慢5倍以上.
+//
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/internal/slice
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkDeleteStrategy/elem8/len_16/head/iter          24.43 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/head/append        16.27 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/head/copy          16.96 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/middle/iter        16.39 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/middle/append      14.93 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/middle/copy        9.202 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/tail/iter          7.367 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/tail/append        8.179 ns/op
+// BenchmarkDeleteStrategy/elem8/len_16/tail/copy          8.585 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/head/iter        586.3 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/head/append      697.9 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/head/copy        698.7 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/middle/iter      411.9 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/middle/append    360.2 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/middle/copy      339.5 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/tail/iter        7.913 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/tail/append      8.560 ns/op
+// BenchmarkDeleteStrategy/elem8/len_1024/tail/copy        6.495 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/head/iter         37.39 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/head/append       22.35 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/head/copy         18.40 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/middle/iter       19.20 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/middle/append     16.77 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/middle/copy       12.96 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/tail/iter         5.054 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/tail/append       5.991 ns/op
+// BenchmarkDeleteStrategy/elem64/len_16/tail/copy         5.931 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/head/iter       2503 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/head/append     1540 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/head/copy       1588 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/middle/iter     1575 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/middle/append   282.9 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/middle/copy     287.6 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/tail/iter       5.131 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/tail/append     6.145 ns/op
+// BenchmarkDeleteStrategy/elem64/len_1024/tail/copy       6.556 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/head/iter        210.4 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/head/append      64.62 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/head/copy        70.07 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/middle/iter      100.4 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/middle/append    43.26 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/middle/copy      51.06 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/tail/iter        7.981 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/tail/append      9.847 ns/op
+// BenchmarkDeleteStrategy/elem512/len_16/tail/copy        8.926 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/head/iter      16328 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/head/append    12380 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/head/copy      12449 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/middle/iter    11412 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/middle/append  6351 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/middle/copy    6079 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/tail/iter      5.356 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/tail/append    6.592 ns/op
+// BenchmarkDeleteStrategy/elem512/len_1024/tail/copy      6.971 ns/op
+func BenchmarkDeleteStrategy(b *testing.B) {
+	b.Run("elem8", func(b *testing.B) { benchmarkDelete[elem8](b) })
+	b.Run("elem64", func(b *testing.B) { benchmarkDelete[elem64](b) })
+	b.Run("elem512", func(b *testing.B) { benchmarkDelete[elem512](b) })
+}
+
+func benchmarkDelete[E any](b *testing.B) {
+	strategies := []struct {
+		name string
+		fn   func([]E, int) ([]E, error)
+	}{
+		{name: "iter", fn: DeleteByIter[[]E, E]},
+		{name: "append", fn: DeleteByAppend[[]E, E]},
+		{name: "copy", fn: DeleteByCopy[[]E, E]},
+	}
+	for _, length := range []int{16, 1024} {
+		for _, pos := range []struct {
+			name  string
+			index func(n int) int
+		}{
+			{name: "head", index: func(n int) int { return 0 }},
+			{name: "middle", index: func(n int) int { return n / 2 }},
+			{name: "tail", index: func(n int) int { return n - 1 }},
+		} {
+			index := pos.index(length)
+			for _, s := range strategies {
+				b.Run(fmt.Sprintf("len_%d/%s/%s", length, pos.name, s.name), func(b *testing.B) {
+					buf := make([]E, length)
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						// 删除后底层数组不变, 重新切片即可恢复长度, 计时中只包含删除本身
+						res, _ := s.fn(buf, index)
+						buf = res[:length]
+					}
+				})
+			}
+		}
+	}
+}
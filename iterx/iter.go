@@ -0,0 +1,210 @@
+// Package iterx 提供惰性的迭代器及其适配器.
+// Seq 和 Seq2 与 Go 1.23 的 iter.Seq 和 iter.Seq2 的定义相同,
+// 升级 Go 版本后可以直接用于 for range.
+package iterx
+
+// Seq 依次将元素传给 yield, yield 返回 false 时停止迭代.
+type Seq[T any] func(yield func(T) bool)
+
+// Seq2 依次将成对的元素传给 yield, yield 返回 false 时停止迭代.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// FromSlice 返回按顺序迭代 src 中元素的 Seq.
+func FromSlice[S ~[]E, E any](src S) Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range src {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect 将 seq 中的元素收集到切片中.
+func Collect[T any](seq Seq[T]) []T {
+	res := make([]T, 0)
+	seq(func(v T) bool {
+		res = append(res, v)
+		return true
+	})
+	return res
+}
+
+// Map 返回对 seq 中每个元素调用 fn 的结果.
+func Map[T, R any](seq Seq[T], fn func(T) R) Seq[R] {
+	return func(yield func(R) bool) {
+		seq(func(v T) bool {
+			return yield(fn(v))
+		})
+	}
+}
+
+// Filter 返回 seq 中 fn 返回 true 的元素.
+func Filter[T any](seq Seq[T], fn func(T) bool) Seq[T] {
+	return func(yield func(T) bool) {
+		seq(func(v T) bool {
+			return !fn(v) || yield(v)
+		})
+	}
+}
+
+// Take 返回 seq 中的前 n 个元素, 取完之后不会再从 seq 中读取元素.
+func Take[T any](seq Seq[T], n int) Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		seq(func(v T) bool {
+			i++
+			return yield(v) && i < n
+		})
+	}
+}
+
+// Skip 跳过 seq 中的前 n 个元素.
+func Skip[T any](seq Seq[T], n int) Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		seq(func(v T) bool {
+			if i < n {
+				i++
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
+
+// Chunk 将 seq 中的元素按照 size 个一组返回, 最后一组可能不足 size 个.
+// 每一组都是新分配的切片. size 必须大于0 否则会 panic.
+func Chunk[T any](seq Seq[T], size int) Seq[[]T] {
+	if size < 1 {
+		panic("ukit: 分块大小必须为正数")
+	}
+	return func(yield func([]T) bool) {
+		var chunk []T
+		stopped := false
+		seq(func(v T) bool {
+			if chunk == nil {
+				chunk = make([]T, 0, size)
+			}
+			chunk = append(chunk, v)
+			if len(chunk) < size {
+				return true
+			}
+			res := chunk
+			chunk = nil
+			stopped = !yield(res)
+			return !stopped
+		})
+		if !stopped && len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Zip 将 a 和 b 中相同位置的元素组成一对, 在较短的一个结束时停止.
+// b 会在单独的 goroutine 中迭代, Zip 返回之前会通知 b 停止并等待它返回,
+// 所以 b 在 yield 返回 false 之后不应该继续阻塞, 否则 Zip 也会一直阻塞.
+// 迭代 b 时发生的 panic, 包括停止之后发生的, 都会在调用方的 goroutine 中重新抛出.
+func Zip[A, B any](a Seq[A], b Seq[B]) Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := pull(b)
+		defer stop()
+		a(func(va A) bool {
+			vb, ok := next()
+			return ok && yield(va, vb)
+		})
+	}
+}
+
+// pull 将 seq 转换为拉取的形式, next 返回下一个元素, 没有更多元素时返回 false.
+// 使用完之后必须调用 stop, stop 会通知 seq 停止并等待迭代 seq 的 goroutine 退出.
+// seq 中发生的 panic 会被 next 或者 stop 在调用方的 goroutine 中重新抛出.
+func pull[T any](seq Seq[T]) (next func() (T, bool), stop func()) {
+	var (
+		req     = make(chan struct{})
+		values  = make(chan T)
+		panics  = make(chan any)
+		done    = make(chan struct{})
+		started bool
+		stopped bool
+	)
+	run := func() {
+		defer close(values)
+		defer func() {
+			// seq 只会在 next 或者 stop 等待时运行, 所以总有一方接收 panic
+			if r := recover(); r != nil {
+				panics <- r
+			}
+		}()
+		select {
+		case <-req:
+		case <-done:
+			return
+		}
+		seq(func(v T) bool {
+			select {
+			case values <- v:
+			case <-done:
+				return false
+			}
+			select {
+			case <-req:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}
+	next = func() (T, bool) {
+		var zero T
+		if stopped {
+			return zero, false
+		}
+		if !started {
+			started = true
+			go run()
+		}
+		select {
+		case req <- struct{}{}:
+		case v, ok := <-values:
+			// 只有 seq 已经迭代结束, values 被关闭时才会走到这里
+			stopped = true
+			return v, ok
+		}
+		select {
+		case v, ok := <-values:
+			if !ok {
+				stopped = true
+			}
+			return v, ok
+		case r := <-panics:
+			stopped = true
+			panic(r)
+		}
+	}
+	stop = func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		close(done)
+		if !started {
+			return
+		}
+		// 等待 goroutine 退出, 停止之后 seq 仍然可能产生元素或者 panic
+		for {
+			select {
+			case _, ok := <-values:
+				if !ok {
+					return
+				}
+			case r := <-panics:
+				panic(r)
+			}
+		}
+	}
+	return next, stop
+}
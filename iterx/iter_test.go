@@ -0,0 +1,186 @@
+package iterx
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countSeq 返回 0, 1, ..., n-1, 并记录产生了多少个元素.
+func countSeq(n int, produced *int) Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			*produced++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, Collect(FromSlice([]int{1, 2, 3})))
+	assert.Equal(t, []int{}, Collect(FromSlice([]int(nil))))
+}
+
+func TestMapFilter(t *testing.T) {
+	seq := Map(Filter(FromSlice([]int{1, 2, 3, 4, 5}), func(v int) bool {
+		return v%2 == 1
+	}), strconv.Itoa)
+	assert.Equal(t, []string{"1", "3", "5"}, Collect(seq))
+}
+
+func TestTake(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            int
+		want         []int
+		wantProduced int
+	}{
+		{name: "zero", n: 0, want: []int{}, wantProduced: 0},
+		{name: "some", n: 3, want: []int{0, 1, 2}, wantProduced: 3},
+		{name: "more_than_len", n: 20, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, wantProduced: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			produced := 0
+			assert.Equal(t, tt.want, Collect(Take(countSeq(10, &produced), tt.n)))
+			// 取完之后不会继续读取
+			assert.Equal(t, tt.wantProduced, produced)
+		})
+	}
+}
+
+func TestSkip(t *testing.T) {
+	produced := 0
+	assert.Equal(t, []int{7, 8, 9}, Collect(Skip(countSeq(10, &produced), 7)))
+	assert.Equal(t, []int{}, Collect(Skip(countSeq(3, &produced), 5)))
+	assert.Equal(t, []int{0, 1}, Collect(Skip(countSeq(2, &produced), -1)))
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		size int
+		want [][]int
+	}{
+		{name: "remainder", n: 5, size: 2, want: [][]int{{0, 1}, {2, 3}, {4}}},
+		{name: "exact", n: 4, size: 2, want: [][]int{{0, 1}, {2, 3}}},
+		{name: "empty", n: 0, size: 2, want: [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			produced := 0
+			assert.Equal(t, tt.want, Collect(Chunk(countSeq(tt.n, &produced), tt.size)))
+		})
+	}
+
+	produced := 0
+	assert.Equal(t, [][]int{{0, 1, 2}}, Collect(Take(Chunk(countSeq(10, &produced), 3), 1)))
+	assert.Equal(t, 3, produced)
+	assert.Panics(t, func() { Chunk(FromSlice([]int{1}), 0) })
+}
+
+func collect2[K, V any](seq Seq2[K, V]) ([]K, []V) {
+	ks, vs := make([]K, 0), make([]V, 0)
+	seq(func(k K, v V) bool {
+		ks = append(ks, k)
+		vs = append(vs, v)
+		return true
+	})
+	return ks, vs
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     []int
+		b     []string
+		wantA []int
+		wantB []string
+	}{
+		{name: "same_length", a: []int{1, 2}, b: []string{"a", "b"}, wantA: []int{1, 2}, wantB: []string{"a", "b"}},
+		{name: "a_shorter", a: []int{1}, b: []string{"a", "b"}, wantA: []int{1}, wantB: []string{"a"}},
+		{name: "b_shorter", a: []int{1, 2}, b: []string{"a"}, wantA: []int{1}, wantB: []string{"a"}},
+		{name: "empty", a: nil, b: []string{"a"}, wantA: []int{}, wantB: []string{}},
+	}
+	before := runtime.NumGoroutine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := collect2(Zip(FromSlice(tt.a), FromSlice(tt.b)))
+			assert.Equal(t, tt.wantA, a)
+			assert.Equal(t, tt.wantB, b)
+		})
+	}
+
+	// 提前停止
+	produced := 0
+	var got []int
+	Zip(FromSlice([]int{1, 2, 3}), countSeq(100, &produced))(func(a, b int) bool {
+		got = append(got, a+b)
+		return len(got) < 2
+	})
+	assert.Equal(t, []int{1, 3}, got)
+	assert.LessOrEqual(t, produced, 3)
+
+	// 迭代 b 的 goroutine 都已经退出
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+func TestZip_Panic(t *testing.T) {
+	b := func(yield func(int) bool) {
+		if yield(1) {
+			panic("boom")
+		}
+	}
+	var got []int
+	assert.PanicsWithValue(t, "boom", func() {
+		Zip(FromSlice([]int{1, 2, 3}), b)(func(a, b int) bool {
+			got = append(got, a+b)
+			return true
+		})
+	})
+	assert.Equal(t, []int{2}, got)
+
+	// panic 之后 next 不再返回元素
+	next, stop := pull[int](b)
+	defer stop()
+	v, ok := next()
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+	assert.PanicsWithValue(t, "boom", func() { next() })
+	_, ok = next()
+	assert.False(t, ok)
+}
+
+func TestZip_PanicAfterStop(t *testing.T) {
+	b := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+		// 停止之后发生的 panic
+		panic("cleanup")
+	}
+	assert.PanicsWithValue(t, "cleanup", func() {
+		Zip(FromSlice([]int{1}), b)(func(a, b int) bool { return true })
+	})
+
+	// stop 会等待 goroutine 退出
+	finished := false
+	next, stop := pull[int](func(yield func(int) bool) {
+		defer func() { finished = true }()
+		for i := 0; yield(i); i++ {
+		}
+		time.Sleep(10 * time.Millisecond)
+	})
+	_, _ = next()
+	stop()
+	assert.True(t, finished)
+	stop() // 重复调用是安全的
+}
@@ -1,5 +1,7 @@
 package queue
 
+import "github.com/udugong/ukit/iterx"
+
 // CircularQueue 循环队列.
 type CircularQueue[T any] struct {
 	capacity int // 容量
@@ -71,3 +73,20 @@ func (c *CircularQueue[T]) IsFull() bool {
 	}
 	return false
 }
+
+// Len 返回队列中元素的个数.
+func (c *CircularQueue[T]) Len() int {
+	return (c.tail - c.head + c.capacity) % c.capacity
+}
+
+// All 按照出队的顺序迭代队列中的元素, 不会修改队列.
+// 迭代过程中不能修改队列.
+func (c *CircularQueue[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := c.head; i != c.tail; i = (i + 1) % c.capacity {
+			if !yield(c.data[i]) {
+				return
+			}
+		}
+	}
+}
@@ -184,6 +184,46 @@ func TestCircularQueue_IsFull(t *testing.T) {
 	}
 }
 
+func TestCircularQueue_Len(t *testing.T) {
+	type testCase[T any] struct {
+		name string
+		c    *CircularQueue[T]
+		want int
+	}
+	tests := []testCase[int]{
+		{
+			name: "empty",
+			c:    NewCircularQueue[int](2),
+			want: 0,
+		},
+		{
+			name: "normal",
+			c: &CircularQueue[int]{
+				capacity: 3,
+				head:     0,
+				tail:     2,
+				data:     []int{1, 2, 0},
+			},
+			want: 2,
+		},
+		{
+			name: "tail_before_head",
+			c: &CircularQueue[int]{
+				capacity: 3,
+				head:     2,
+				tail:     1,
+				data:     []int{2, 0, 1},
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.c.Len())
+		})
+	}
+}
+
 func TestCircularQueueLifecycle(t *testing.T) {
 	cq := NewCircularQueue[int](2)
 	type testCase[T any] struct {
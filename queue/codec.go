@@ -0,0 +1,305 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+
+	"github.com/udugong/ukit/heap"
+)
+
+var (
+	errInvalidQueueData      = errors.New("ukit: 非法的队列数据")
+	errUninitializedPriority = errors.New("ukit: 优先队列必须使用 NewPriorityQueue 或 NewPriorityQueueFunc 创建")
+)
+
+// maxDecodedCapacity 反序列化时允许的最大容量.
+// 循环队列会按照容量一次性分配内存, 限制容量可以避免恶意数据导致溢出或者分配过多的内存.
+const maxDecodedCapacity = 1 << 24
+
+// boundedState 有界队列序列化后的状态.
+// Elements 按照出队的顺序排列.
+type boundedState[T any] struct {
+	Capacity int `json:"capacity"`
+	Elements []T `json:"elements"`
+}
+
+func gobEncode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gobDecode(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// elements 按照出队的顺序返回队列中的元素.
+func (c *CircularQueue[T]) elements() []T {
+	res := make([]T, 0, c.Len())
+	for i := c.head; i != c.tail; i = (i + 1) % c.capacity {
+		res = append(res, c.data[i])
+	}
+	return res
+}
+
+func (c *CircularQueue[T]) state() boundedState[T] {
+	return boundedState[T]{Capacity: c.capacity - 1, Elements: c.elements()}
+}
+
+func (c *CircularQueue[T]) restore(state boundedState[T]) error {
+	if state.Capacity < 1 || state.Capacity > maxDecodedCapacity || len(state.Elements) > state.Capacity {
+		return errInvalidQueueData
+	}
+	res := NewCircularQueue[T](state.Capacity)
+	for _, val := range state.Elements {
+		_ = res.Enqueue(val)
+	}
+	*c = *res
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler.
+// 格式为 {"capacity": 容量, "elements": [按照出队顺序排列的元素]}.
+func (c *CircularQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.state())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+// 如果元素个数超过容量, 或者容量超过 maxDecodedCapacity 则返回错误.
+func (c *CircularQueue[T]) UnmarshalJSON(data []byte) error {
+	var state boundedState[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	return c.restore(state)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码.
+func (c *CircularQueue[T]) MarshalBinary() ([]byte, error) {
+	return gobEncode(c.state())
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (c *CircularQueue[T]) UnmarshalBinary(data []byte) error {
+	var state boundedState[T]
+	if err := gobDecode(data, &state); err != nil {
+		return err
+	}
+	return c.restore(state)
+}
+
+// elements 按照出队的顺序返回队列中的元素.
+func (l *LinkedQueue[T]) elements() []T {
+	res := make([]T, 0, l.length)
+	for node := l.head; node != nil; node = node.next {
+		res = append(res, node.val)
+	}
+	return res
+}
+
+func (l *LinkedQueue[T]) restore(elements []T) {
+	*l = LinkedQueue[T]{}
+	for _, val := range elements {
+		_ = l.Enqueue(val)
+	}
+}
+
+// MarshalJSON 实现 json.Marshaler, 队列会被序列化为按照出队顺序排列的 JSON 数组.
+func (l *LinkedQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.elements())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+// 会替换队列中原有的元素.
+func (l *LinkedQueue[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	l.restore(elements)
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码.
+func (l *LinkedQueue[T]) MarshalBinary() ([]byte, error) {
+	return gobEncode(l.elements())
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (l *LinkedQueue[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := gobDecode(data, &elements); err != nil {
+		return err
+	}
+	l.restore(elements)
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler, 格式与 LinkedQueue 相同.
+func (c *ConcurrentLinkedQueue[T]) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.MarshalJSON()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+// 会替换队列中原有的元素.
+func (c *ConcurrentLinkedQueue[T]) UnmarshalJSON(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.queue == nil {
+		c.queue = NewLinkedQueue[T]()
+	}
+	return c.queue.UnmarshalJSON(data)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码.
+func (c *ConcurrentLinkedQueue[T]) MarshalBinary() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.MarshalBinary()
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (c *ConcurrentLinkedQueue[T]) UnmarshalBinary(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.queue == nil {
+		c.queue = NewLinkedQueue[T]()
+	}
+	return c.queue.UnmarshalBinary(data)
+}
+
+// blockingState 并发阻塞队列序列化后的状态, 在有界队列的基础上保存了是否已关闭.
+type blockingState[T any] struct {
+	Capacity int  `json:"capacity"`
+	Elements []T  `json:"elements"`
+	Closed   bool `json:"closed"`
+}
+
+func (c *ConcurrentBlockingQueue[T]) state() blockingState[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state := c.queue.state()
+	return blockingState[T]{Capacity: state.Capacity, Elements: state.Elements, Closed: c.closed}
+}
+
+// restore 替换队列中的元素和关闭状态, 并唤醒所有阻塞的调用重新检查条件.
+func (c *ConcurrentBlockingQueue[T]) restore(state blockingState[T]) error {
+	var queue CircularQueue[T]
+	if err := queue.restore(boundedState[T]{Capacity: state.Capacity, Elements: state.Elements}); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.notEmpty == nil {
+		c.notEmpty = newCond(&c.mu)
+		c.notFull = newCond(&c.mu)
+	}
+	c.queue = &queue
+	c.closed = state.Closed
+	c.notEmpty.Broadcast()
+	c.notFull.Broadcast()
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler.
+// 格式为 {"capacity": 容量, "elements": [按照出队顺序排列的元素], "closed": 是否已关闭}.
+func (c *ConcurrentBlockingQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.state())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+// 会替换队列中原有的元素和关闭状态, 元素个数或容量不合法时返回错误.
+func (c *ConcurrentBlockingQueue[T]) UnmarshalJSON(data []byte) error {
+	var state blockingState[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	return c.restore(state)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码.
+func (c *ConcurrentBlockingQueue[T]) MarshalBinary() ([]byte, error) {
+	return gobEncode(c.state())
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (c *ConcurrentBlockingQueue[T]) UnmarshalBinary(data []byte) error {
+	var state blockingState[T]
+	if err := gobDecode(data, &state); err != nil {
+		return err
+	}
+	return c.restore(state)
+}
+
+// LockFreeLinkedQueue 和 LockFreeRingQueue 没有实现序列化.
+// 它们没有锁, 无法在其他生产者和消费者并发调用时得到一致的快照,
+// 反序列化时也无法安全地替换正在被并发访问的节点或槽位.
+// 需要持久化时可以先停止读写, 再通过 Drain 取出元素.
+
+// elements 按照出队的顺序返回队列中的元素.
+func (p *PriorityQueue[T]) elements() []T {
+	h := &priorityHeap[T]{
+		data: append([]T(nil), p.data.data...),
+		less: p.data.less,
+	}
+	res := make([]T, 0, h.Len())
+	for h.Len() > 0 {
+		res = append(res, heap.Pop[T](h))
+	}
+	return res
+}
+
+func (p *PriorityQueue[T]) state() boundedState[T] {
+	return boundedState[T]{Capacity: p.capacity, Elements: p.elements()}
+}
+
+// restore 比较函数无法序列化, 所以只能恢复到已经创建好的优先队列中.
+func (p *PriorityQueue[T]) restore(state boundedState[T]) error {
+	if p.data == nil || p.data.less == nil {
+		return errUninitializedPriority
+	}
+	if state.Capacity > 0 && len(state.Elements) > state.Capacity {
+		return errInvalidQueueData
+	}
+	p.capacity = state.Capacity
+	p.data.data = state.Elements
+	heap.Init[T](p.data)
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler.
+// 格式为 {"capacity": 容量, "elements": [按照出队顺序排列的元素]}, 容量为0表示无界.
+func (p *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.state())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler.
+// 比较函数无法序列化, 所以 p 必须使用 NewPriorityQueue 或 NewPriorityQueueFunc 创建.
+func (p *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	var state boundedState[T]
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	return p.restore(state)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码.
+func (p *PriorityQueue[T]) MarshalBinary() ([]byte, error) {
+	return gobEncode(p.state())
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+// 与 UnmarshalJSON 一样, p 必须使用 NewPriorityQueue 或 NewPriorityQueueFunc 创建.
+func (p *PriorityQueue[T]) UnmarshalBinary(data []byte) error {
+	var state boundedState[T]
+	if err := gobDecode(data, &state); err != nil {
+		return err
+	}
+	return p.restore(state)
+}
@@ -0,0 +1,211 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+func TestCircularQueue_MarshalJSON(t *testing.T) {
+	cq := NewCircularQueue[int](3)
+	// 让 tail 回绕到 head 之前
+	for i := 1; i <= 3; i++ {
+		_ = cq.Enqueue(i)
+	}
+	_, _ = cq.Dequeue()
+	_ = cq.Enqueue(4)
+
+	data, err := json.Marshal(cq)
+	require.NoError(t, err)
+	assert.Equal(t, `{"capacity":3,"elements":[2,3,4]}`, string(data))
+
+	var got CircularQueue[int]
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, 3, got.Len())
+	assert.True(t, got.IsFull())
+	for i := 2; i <= 4; i++ {
+		val, err := got.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestCircularQueue_UnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "zero_capacity", data: `{"capacity":0,"elements":[]}`},
+		{name: "too_many_elements", data: `{"capacity":1,"elements":[1,2]}`},
+		{name: "overflow_capacity", data: `{"capacity":9223372036854775807,"elements":[]}`},
+		{name: "oversized_capacity", data: `{"capacity":16777217,"elements":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cq CircularQueue[int]
+			assert.Equal(t, errInvalidQueueData, json.Unmarshal([]byte(tt.data), &cq))
+			var bq ConcurrentBlockingQueue[int]
+			assert.Equal(t, errInvalidQueueData, json.Unmarshal([]byte(tt.data), &bq))
+		})
+	}
+
+	// 二进制格式使用相同的校验
+	data, err := gobEncode(boundedState[int]{Capacity: math.MaxInt})
+	require.NoError(t, err)
+	var cq CircularQueue[int]
+	assert.Equal(t, errInvalidQueueData, cq.UnmarshalBinary(data))
+	data, err = gobEncode(blockingState[int]{Capacity: math.MaxInt})
+	require.NoError(t, err)
+	var bq ConcurrentBlockingQueue[int]
+	assert.Equal(t, errInvalidQueueData, bq.UnmarshalBinary(data))
+}
+
+func TestLinkedQueue_MarshalJSON(t *testing.T) {
+	q := NewLinkedQueue[string]()
+	_ = q.Enqueue("a")
+	_ = q.Enqueue("b")
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, string(data))
+
+	got := NewLinkedQueue[string]()
+	_ = got.Enqueue("old") // 原有的元素会被替换
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, []string{"a", "b"}, got.elements())
+	assert.Equal(t, 2, got.Len())
+
+	empty, err := json.Marshal(NewLinkedQueue[int]())
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(empty))
+}
+
+func TestConcurrentLinkedQueue_MarshalJSON(t *testing.T) {
+	q := NewConcurrentLinkedQueue[int]()
+	_ = q.Enqueue(1)
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+	assert.Equal(t, `[1]`, string(data))
+
+	var got ConcurrentLinkedQueue[int]
+	require.NoError(t, json.Unmarshal(data, &got))
+	val, err := got.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+}
+
+func TestPriorityQueue_MarshalJSON(t *testing.T) {
+	pq := NewPriorityQueue[int](5)
+	for _, val := range []int{3, 1, 2} {
+		_ = pq.Enqueue(val)
+	}
+	data, err := json.Marshal(pq)
+	require.NoError(t, err)
+	assert.Equal(t, `{"capacity":5,"elements":[1,2,3]}`, string(data))
+	assert.Equal(t, 3, pq.Len()) // 序列化不会修改队列
+
+	got := NewPriorityQueue[int](0)
+	require.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, 5, got.Cap())
+	for i := 1; i <= 3; i++ {
+		val, err := got.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, i, val)
+	}
+
+	var uninitialized PriorityQueue[int]
+	assert.Equal(t, errUninitializedPriority, json.Unmarshal(data, &uninitialized))
+	assert.Equal(t, errInvalidQueueData,
+		json.Unmarshal([]byte(`{"capacity":1,"elements":[1,2]}`), NewPriorityQueue[int](0)))
+}
+
+func TestConcurrentBlockingQueue_MarshalJSON(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](3)
+	_ = q.TryEnqueue(1)
+	_ = q.TryEnqueue(2)
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+	assert.Equal(t, `{"capacity":3,"elements":[1,2],"closed":false}`, string(data))
+
+	var got ConcurrentBlockingQueue[int]
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, 2, got.Len())
+	require.NoError(t, got.TryEnqueue(3))
+	assert.Equal(t, ErrFullQueue, got.TryEnqueue(4))
+	val, err := got.Dequeue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	// 关闭状态会被保留
+	q.Close()
+	data, err = json.Marshal(q)
+	require.NoError(t, err)
+	assert.Equal(t, `{"capacity":3,"elements":[1,2],"closed":true}`, string(data))
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, ErrClosedQueue, got.TryEnqueue(3))
+	assert.Equal(t, []int{1, 2}, iterx.Collect(got.All()))
+
+	assert.Equal(t, errInvalidQueueData,
+		json.Unmarshal([]byte(`{"capacity":1,"elements":[1,2]}`), &got))
+	assert.Equal(t, errInvalidQueueData, json.Unmarshal([]byte(`{"capacity":0}`), &got))
+}
+
+func TestConcurrentBlockingQueue_UnmarshalWakesWaiters(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](1)
+	res := make(chan int)
+	go func() {
+		val, _ := q.Dequeue(context.Background())
+		res <- val
+	}()
+	require.NoError(t, json.Unmarshal([]byte(`{"capacity":1,"elements":[7]}`), q))
+	select {
+	case val := <-res:
+		assert.Equal(t, 7, val)
+	case <-time.After(time.Second):
+		t.Fatal("阻塞的 Dequeue 没有被唤醒")
+	}
+}
+
+func TestQueue_Gob(t *testing.T) {
+	type payload struct {
+		Circular *CircularQueue[int]
+		Linked   *LinkedQueue[int]
+		Priority *PriorityQueue[int]
+		Blocking *ConcurrentBlockingQueue[int]
+	}
+	want := payload{
+		Circular: NewCircularQueue[int](2),
+		Linked:   NewLinkedQueue[int](),
+		Priority: NewPriorityQueue[int](0),
+		Blocking: NewConcurrentBlockingQueue[int](2),
+	}
+	for _, val := range []int{2, 1} {
+		_ = want.Circular.Enqueue(val)
+		_ = want.Linked.Enqueue(val)
+		_ = want.Priority.Enqueue(val)
+		_ = want.Blocking.TryEnqueue(val)
+	}
+	want.Blocking.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(want))
+	got := payload{Priority: NewPriorityQueue[int](0)}
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+
+	assert.Equal(t, []int{2, 1}, got.Circular.elements())
+	assert.Equal(t, []int{2, 1}, got.Linked.elements())
+	assert.Equal(t, []int{1, 2}, got.Priority.elements())
+	assert.Equal(t, []int{2, 1}, got.Blocking.queue.elements())
+	assert.True(t, got.Blocking.closed)
+
+	var cq CircularQueue[int]
+	assert.Error(t, cq.UnmarshalBinary([]byte("invalid")))
+}
@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+// ConcurrentBlockingQueue 基于循环队列实现的并发安全的有界阻塞队列.
+type ConcurrentBlockingQueue[T any] struct {
+	mu       sync.Mutex
+	queue    *CircularQueue[T]
+	notEmpty *cond // 队列不为空或已关闭时唤醒
+	notFull  *cond // 队列不满或已关闭时唤醒
+	closed   bool
+}
+
+// NewConcurrentBlockingQueue 创建一个并发阻塞队列.
+// capacity 必须大于0 否则会 panic.
+func NewConcurrentBlockingQueue[T any](capacity int) *ConcurrentBlockingQueue[T] {
+	q := &ConcurrentBlockingQueue[T]{
+		queue: NewCircularQueue[T](capacity),
+	}
+	q.notEmpty = newCond(&q.mu)
+	q.notFull = newCond(&q.mu)
+	return q
+}
+
+// Enqueue 入队.
+// 如果队列已满则阻塞直到有空位, ctx 超时或被取消时返回 ctx.Err().
+// 如果队列已关闭则返回 ErrClosedQueue 错误.
+func (c *ConcurrentBlockingQueue[T]) Enqueue(ctx context.Context, val T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.closed && c.queue.IsFull() {
+		if err := c.notFull.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if c.closed {
+		return ErrClosedQueue
+	}
+	_ = c.queue.Enqueue(val)
+	c.notEmpty.Broadcast()
+	return nil
+}
+
+// Dequeue 出队.
+// 如果队列为空则阻塞直到有元素, ctx 超时或被取消时返回 ctx.Err().
+// 队列关闭后仍然可以取出剩余的元素, 取完后返回 ErrEmptyQueue 错误.
+func (c *ConcurrentBlockingQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.closed && c.queue.IsEmpty() {
+		if err := c.notEmpty.Wait(ctx); err != nil {
+			var t T
+			return t, err
+		}
+	}
+	val, err := c.queue.Dequeue()
+	if err == nil {
+		c.notFull.Broadcast()
+	}
+	return val, err
+}
+
+// TryEnqueue 非阻塞入队.
+// 如果队列已满则返回 ErrFullQueue 错误.
+// 如果队列已关闭则返回 ErrClosedQueue 错误.
+func (c *ConcurrentBlockingQueue[T]) TryEnqueue(val T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrClosedQueue
+	}
+	if err := c.queue.Enqueue(val); err != nil {
+		return err
+	}
+	c.notEmpty.Broadcast()
+	return nil
+}
+
+// TryDequeue 非阻塞出队.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (c *ConcurrentBlockingQueue[T]) TryDequeue() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	val, err := c.queue.Dequeue()
+	if err == nil {
+		c.notFull.Broadcast()
+	}
+	return val, err
+}
+
+// Len 返回队列中元素的个数.
+func (c *ConcurrentBlockingQueue[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Len()
+}
+
+// Close 关闭队列.
+// 关闭后 Enqueue 返回 ErrClosedQueue 错误, 所有阻塞的调用都会被唤醒.
+// Dequeue 仍然可以取出剩余的元素, 以便优雅退出.
+// 重复调用 Close 是安全的.
+func (c *ConcurrentBlockingQueue[T]) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	c.closed = true
+	c.notEmpty.Broadcast()
+	c.notFull.Broadcast()
+}
+
+// All 按照出队的顺序迭代调用时队列中元素的快照, 迭代过程中可以修改队列.
+func (c *ConcurrentBlockingQueue[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		c.mu.Lock()
+		elements := c.queue.elements()
+		c.mu.Unlock()
+		iterx.FromSlice(elements)(yield)
+	}
+}
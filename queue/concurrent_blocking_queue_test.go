@@ -0,0 +1,217 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentBlockingQueue_Enqueue(t *testing.T) {
+	type testCase[T any] struct {
+		name    string
+		q       func() *ConcurrentBlockingQueue[T]
+		timeout time.Duration
+		val     T
+		wantErr error
+		wantLen int
+	}
+	tests := []testCase[int]{
+		{
+			name: "normal",
+			q: func() *ConcurrentBlockingQueue[int] {
+				return NewConcurrentBlockingQueue[int](1)
+			},
+			timeout: time.Second,
+			val:     1,
+			wantLen: 1,
+		},
+		{
+			name: "timeout",
+			q: func() *ConcurrentBlockingQueue[int] {
+				q := NewConcurrentBlockingQueue[int](1)
+				_ = q.TryEnqueue(1)
+				return q
+			},
+			timeout: 10 * time.Millisecond,
+			val:     2,
+			wantErr: context.DeadlineExceeded,
+			wantLen: 1,
+		},
+		{
+			name: "closed",
+			q: func() *ConcurrentBlockingQueue[int] {
+				q := NewConcurrentBlockingQueue[int](1)
+				q.Close()
+				return q
+			},
+			timeout: time.Second,
+			val:     1,
+			wantErr: ErrClosedQueue,
+			wantLen: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			q := tt.q()
+			assert.Equal(t, tt.wantErr, q.Enqueue(ctx, tt.val))
+			assert.Equal(t, tt.wantLen, q.Len())
+		})
+	}
+}
+
+func TestConcurrentBlockingQueue_Dequeue(t *testing.T) {
+	type testCase[T any] struct {
+		name    string
+		q       func() *ConcurrentBlockingQueue[T]
+		timeout time.Duration
+		want    T
+		wantErr error
+	}
+	tests := []testCase[int]{
+		{
+			name: "normal",
+			q: func() *ConcurrentBlockingQueue[int] {
+				q := NewConcurrentBlockingQueue[int](1)
+				_ = q.TryEnqueue(1)
+				return q
+			},
+			timeout: time.Second,
+			want:    1,
+		},
+		{
+			name: "timeout",
+			q: func() *ConcurrentBlockingQueue[int] {
+				return NewConcurrentBlockingQueue[int](1)
+			},
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name: "closed_with_remaining",
+			q: func() *ConcurrentBlockingQueue[int] {
+				q := NewConcurrentBlockingQueue[int](1)
+				_ = q.TryEnqueue(1)
+				q.Close()
+				return q
+			},
+			timeout: time.Second,
+			want:    1,
+		},
+		{
+			name: "closed_and_empty",
+			q: func() *ConcurrentBlockingQueue[int] {
+				q := NewConcurrentBlockingQueue[int](1)
+				q.Close()
+				return q
+			},
+			timeout: time.Second,
+			wantErr: ErrEmptyQueue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			got, err := tt.q().Dequeue(ctx)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConcurrentBlockingQueue_TryEnqueue(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](1)
+	assert.NoError(t, q.TryEnqueue(1))
+	assert.Equal(t, ErrFullQueue, q.TryEnqueue(2))
+	q.Close()
+	assert.Equal(t, ErrClosedQueue, q.TryEnqueue(3))
+}
+
+func TestConcurrentBlockingQueue_TryDequeue(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](1)
+	_, err := q.TryDequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+	_ = q.TryEnqueue(1)
+	got, err := q.TryDequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func TestConcurrentBlockingQueue_Close(t *testing.T) {
+	q := NewConcurrentBlockingQueue[int](1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// 阻塞的 Dequeue 应该被 Close 唤醒
+		_, err := q.Dequeue(context.Background())
+		assert.Equal(t, ErrEmptyQueue, err)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	q.Close()
+	wg.Wait()
+
+	full := NewConcurrentBlockingQueue[int](1)
+	_ = full.TryEnqueue(1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// 阻塞的 Enqueue 应该被 Close 唤醒
+		assert.Equal(t, ErrClosedQueue, full.Enqueue(context.Background(), 2))
+	}()
+	time.Sleep(10 * time.Millisecond)
+	full.Close()
+	wg.Wait()
+}
+
+func TestConcurrentBlockingQueue_Concurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		n         = 1000
+	)
+	q := NewConcurrentBlockingQueue[int](8)
+	ctx := context.Background()
+
+	var producerWg sync.WaitGroup
+	producerWg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer producerWg.Done()
+			for i := 0; i < n; i++ {
+				assert.NoError(t, q.Enqueue(ctx, p*n+i))
+			}
+		}(p)
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]struct{}, producers*n)
+	var consumerWg sync.WaitGroup
+	consumerWg.Add(consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			defer consumerWg.Done()
+			for {
+				val, err := q.Dequeue(ctx)
+				if err != nil {
+					assert.Equal(t, ErrEmptyQueue, err)
+					return
+				}
+				mu.Lock()
+				seen[val] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producerWg.Wait()
+	q.Close()
+	consumerWg.Wait()
+	assert.Equal(t, producers*n, len(seen))
+}
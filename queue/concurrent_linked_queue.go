@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"sync"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+// ConcurrentLinkedQueue 并发安全的无界链表队列.
+type ConcurrentLinkedQueue[T any] struct {
+	mu    sync.Mutex
+	queue *LinkedQueue[T]
+}
+
+// NewConcurrentLinkedQueue 创建一个并发安全的链表队列.
+func NewConcurrentLinkedQueue[T any]() *ConcurrentLinkedQueue[T] {
+	return &ConcurrentLinkedQueue[T]{
+		queue: NewLinkedQueue[T](),
+	}
+}
+
+// Enqueue 入队.
+// 队列是无界的, 所以总是返回 nil.
+func (c *ConcurrentLinkedQueue[T]) Enqueue(val T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Enqueue(val)
+}
+
+// Dequeue 出队.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (c *ConcurrentLinkedQueue[T]) Dequeue() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Dequeue()
+}
+
+// Peek 查看队头元素.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (c *ConcurrentLinkedQueue[T]) Peek() (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Peek()
+}
+
+// IsEmpty 队列为空.
+func (c *ConcurrentLinkedQueue[T]) IsEmpty() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.IsEmpty()
+}
+
+// Len 返回队列中元素的个数.
+func (c *ConcurrentLinkedQueue[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Len()
+}
+
+// All 按照出队的顺序迭代调用时队列中元素的快照, 迭代过程中可以修改队列.
+func (c *ConcurrentLinkedQueue[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		c.mu.Lock()
+		elements := c.queue.elements()
+		c.mu.Unlock()
+		iterx.FromSlice(elements)(yield)
+	}
+}
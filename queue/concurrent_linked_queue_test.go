@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentLinkedQueue_Peek(t *testing.T) {
+	q := NewConcurrentLinkedQueue[int]()
+	_, err := q.Peek()
+	assert.Equal(t, ErrEmptyQueue, err)
+	assert.True(t, q.IsEmpty())
+
+	_ = q.Enqueue(1)
+	got, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+	assert.Equal(t, 1, q.Len())
+	assert.False(t, q.IsEmpty())
+}
+
+func TestConcurrentLinkedQueue_Concurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		n          = 1000
+	)
+	q := NewConcurrentLinkedQueue[int]()
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				_ = q.Enqueue(g*n + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, goroutines*n, q.Len())
+
+	seen := make(map[int]struct{}, goroutines*n)
+	var mu sync.Mutex
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for {
+				val, err := q.Dequeue()
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				seen[val] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, goroutines*n, len(seen))
+}
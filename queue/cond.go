@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"context"
+	"sync"
+)
+
+// cond 支持 context 的条件变量.
+// 与 sync.Cond 不同, Wait 可以被 context 的超时或取消打断.
+type cond struct {
+	L  sync.Locker
+	ch chan struct{} // 懒加载, 只有存在等待者时才会创建
+}
+
+func newCond(l sync.Locker) *cond {
+	return &cond{L: l}
+}
+
+// Wait 释放锁并等待 Broadcast 或 ctx 结束, 返回前重新获取锁.
+// 调用前必须持有 c.L.
+// 如果因为 ctx 结束而返回则返回 ctx.Err().
+func (c *cond) Wait(ctx context.Context) error {
+	if c.ch == nil {
+		c.ch = make(chan struct{})
+	}
+	ch := c.ch
+	c.L.Unlock()
+	defer c.L.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Broadcast 唤醒所有的等待者.
+// 调用前必须持有 c.L.
+func (c *cond) Broadcast() {
+	if c.ch != nil {
+		close(c.ch)
+		c.ch = nil
+	}
+}
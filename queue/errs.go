@@ -3,6 +3,7 @@ package queue
 import "errors"
 
 var (
-	ErrEmptyQueue = errors.New("ukit: 队列为空")
-	ErrFullQueue  = errors.New("ukit: 队列已满")
+	ErrEmptyQueue  = errors.New("ukit: 队列为空")
+	ErrFullQueue   = errors.New("ukit: 队列已满")
+	ErrClosedQueue = errors.New("ukit: 队列已关闭")
 )
@@ -0,0 +1,118 @@
+package queue
+
+import "github.com/udugong/ukit/iterx"
+
+// maxFreeNodes 空闲链表最多缓存的节点数量.
+// 限制缓存数量以免突发流量之后一直占用内存.
+const maxFreeNodes = 1024
+
+type linkedNode[T any] struct {
+	val  T
+	next *linkedNode[T]
+}
+
+// LinkedQueue 基于单链表实现的无界队列.
+// 出队的节点会被缓存起来供后续入队复用, 以减少内存分配.
+type LinkedQueue[T any] struct {
+	head   *linkedNode[T] // 队头节点
+	tail   *linkedNode[T] // 队尾节点
+	length int            // 队列中元素的个数
+
+	free       *linkedNode[T] // 空闲节点链表
+	freeLength int            // 空闲节点的个数
+}
+
+// NewLinkedQueue 创建一个链表队列.
+func NewLinkedQueue[T any]() *LinkedQueue[T] {
+	return &LinkedQueue[T]{}
+}
+
+// Enqueue 入队.
+// 队列是无界的, 所以总是返回 nil.
+func (l *LinkedQueue[T]) Enqueue(val T) error {
+	node := l.newNode(val)
+	if l.tail == nil {
+		l.head = node
+	} else {
+		l.tail.next = node
+	}
+	l.tail = node
+	l.length++
+	return nil
+}
+
+// Dequeue 出队.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (l *LinkedQueue[T]) Dequeue() (T, error) {
+	if l.head == nil {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	node := l.head
+	l.head = node.next
+	if l.head == nil {
+		l.tail = nil
+	}
+	l.length--
+	val := node.val
+	l.freeNode(node)
+	return val, nil
+}
+
+// Peek 查看队头元素.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (l *LinkedQueue[T]) Peek() (T, error) {
+	if l.head == nil {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	return l.head.val, nil
+}
+
+// IsEmpty 队列为空.
+func (l *LinkedQueue[T]) IsEmpty() bool {
+	return l.length == 0
+}
+
+// Len 返回队列中元素的个数.
+func (l *LinkedQueue[T]) Len() int {
+	return l.length
+}
+
+// newNode 优先从空闲链表中获取节点.
+func (l *LinkedQueue[T]) newNode(val T) *linkedNode[T] {
+	if l.free == nil {
+		return &linkedNode[T]{val: val}
+	}
+	node := l.free
+	l.free = node.next
+	l.freeLength--
+	node.val = val
+	node.next = nil
+	return node
+}
+
+// freeNode 将节点放回空闲链表.
+// 会清空节点中的值, 避免持有已出队元素的引用.
+func (l *LinkedQueue[T]) freeNode(node *linkedNode[T]) {
+	if l.freeLength >= maxFreeNodes {
+		return
+	}
+	var t T
+	node.val = t
+	node.next = l.free
+	l.free = node
+	l.freeLength++
+}
+
+// All 按照出队的顺序迭代队列中的元素, 不会修改队列.
+// 迭代过程中不能修改队列.
+func (l *LinkedQueue[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.head; node != nil; node = node.next {
+			if !yield(node.val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedQueue_Peek(t *testing.T) {
+	q := NewLinkedQueue[int]()
+	_, err := q.Peek()
+	assert.Equal(t, ErrEmptyQueue, err)
+
+	_ = q.Enqueue(1)
+	_ = q.Enqueue(2)
+	got, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestLinkedQueue_Len(t *testing.T) {
+	q := NewLinkedQueue[int]()
+	assert.True(t, q.IsEmpty())
+	assert.Equal(t, 0, q.Len())
+	for i := 0; i < 3; i++ {
+		_ = q.Enqueue(i)
+	}
+	assert.False(t, q.IsEmpty())
+	assert.Equal(t, 3, q.Len())
+	_, _ = q.Dequeue()
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestLinkedQueue_NodePool(t *testing.T) {
+	q := NewLinkedQueue[*int]()
+	for i := 0; i < 3; i++ {
+		v := i
+		_ = q.Enqueue(&v)
+	}
+	for i := 0; i < 3; i++ {
+		_, _ = q.Dequeue()
+	}
+	assert.Equal(t, 3, q.freeLength)
+	// 空闲节点不能持有已出队元素的引用
+	for node := q.free; node != nil; node = node.next {
+		assert.Nil(t, node.val)
+	}
+
+	v := 10
+	_ = q.Enqueue(&v)
+	assert.Equal(t, 2, q.freeLength)
+	got, err := q.Dequeue()
+	assert.NoError(t, err)
+	assert.Equal(t, &v, got)
+
+	for i := 0; i < maxFreeNodes+10; i++ {
+		_ = q.Enqueue(nil)
+	}
+	for !q.IsEmpty() {
+		_, _ = q.Dequeue()
+	}
+	assert.Equal(t, maxFreeNodes, q.freeLength)
+}
+
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/queue
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkLinkedQueue   196772517   6.200 ns/op   0 B/op   0 allocs/op
+func BenchmarkLinkedQueue(b *testing.B) {
+	q := NewLinkedQueue[int]()
+	for i := 0; i < b.N; i++ {
+		_ = q.Enqueue(i)
+		_, _ = q.Dequeue()
+	}
+}
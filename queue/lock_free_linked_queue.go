@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"sync/atomic"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+type lockFreeNode[T any] struct {
+	val  T
+	next atomic.Pointer[lockFreeNode[T]]
+}
+
+// LockFreeLinkedQueue 基于 Michael-Scott 算法实现的无锁无界队列.
+// 支持多个生产者和多个消费者并发调用, Enqueue 和 Dequeue 都不会阻塞.
+type LockFreeLinkedQueue[T any] struct {
+	_    [cacheLineSize]byte
+	head atomic.Pointer[lockFreeNode[T]] // 指向哨兵节点, 队头元素为 head.next
+	_    [cacheLineSize - 8]byte
+	tail atomic.Pointer[lockFreeNode[T]] // 指向最后一个节点或者倒数第二个节点
+	_    [cacheLineSize - 8]byte
+}
+
+// NewLockFreeLinkedQueue 创建一个无锁链表队列.
+func NewLockFreeLinkedQueue[T any]() *LockFreeLinkedQueue[T] {
+	q := &LockFreeLinkedQueue[T]{}
+	sentinel := &lockFreeNode[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Enqueue 入队.
+// 队列是无界的, 所以总是返回 nil.
+func (q *LockFreeLinkedQueue[T]) Enqueue(val T) error {
+	node := &lockFreeNode[T]{val: val}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail 落后了, 帮助其他生产者推进 tail
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, node) {
+			q.tail.CompareAndSwap(tail, node)
+			return nil
+		}
+	}
+}
+
+// Dequeue 出队.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+// 出队的节点会成为新的哨兵节点, 它的值在下一次出队之前不会被回收.
+func (q *LockFreeLinkedQueue[T]) Dequeue() (T, error) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if head == tail {
+			if next == nil {
+				var t T
+				return t, ErrEmptyQueue
+			}
+			// tail 落后了, 帮助其他生产者推进 tail
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// next.val 在节点发布之后不会再被修改, 可以安全读取
+		val := next.val
+		if q.head.CompareAndSwap(head, next) {
+			return val, nil
+		}
+	}
+}
+
+// Drain 不断地出队并产生出队的元素, 直到队列为空或者停止迭代.
+func (q *LockFreeLinkedQueue[T]) Drain() iterx.Seq[T] {
+	return drain[T](q)
+}
+
+// drain 返回一个不断出队的迭代器, 直到 Dequeue 返回错误或者停止迭代.
+// 无锁队列无法在其他消费者并发出队时安全地遍历元素, 所以只提供会消费元素的迭代器.
+func drain[T any](q Queue[T]) iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, err := q.Dequeue()
+			if err != nil || !yield(val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockFreeLinkedQueue_EnqueueDequeue(t *testing.T) {
+	q := NewLockFreeLinkedQueue[int]()
+	_, err := q.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, q.Enqueue(i))
+	}
+	for i := 0; i < 100; i++ {
+		got, err := q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, i, got)
+	}
+
+	got, err := q.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+	assert.Equal(t, 0, got)
+}
+
+func TestLockFreeLinkedQueue_Concurrent(t *testing.T) {
+	testLockFreeQueueConcurrent(t, NewLockFreeLinkedQueue[int]())
+}
+
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/queue
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkLockFreeLinkedQueue   16214533   85.78 ns/op   16 B/op   1 allocs/op
+func BenchmarkLockFreeLinkedQueue(b *testing.B) {
+	q := NewLockFreeLinkedQueue[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = q.Enqueue(1)
+			_, _ = q.Dequeue()
+		}
+	})
+}
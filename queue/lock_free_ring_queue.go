@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"sync/atomic"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+// cacheLineSize 用于填充, 避免伪共享.
+const cacheLineSize = 64
+
+// ringCell 环形缓冲区中的槽位.
+// seq 标识该槽位当前允许的操作:
+// seq == pos 时可以写入, seq == pos+1 时可以读取.
+type ringCell[T any] struct {
+	seq atomic.Uint64
+	val T
+}
+
+// LockFreeRingQueue 基于 Dmitry Vyukov 的有界 MPMC 算法实现的无锁环形队列.
+// 支持多个生产者和多个消费者并发调用, Enqueue 和 Dequeue 都不会阻塞.
+type LockFreeRingQueue[T any] struct {
+	_    [cacheLineSize]byte
+	tail atomic.Uint64 // 下一个入队的位置
+	_    [cacheLineSize - 8]byte
+	head atomic.Uint64 // 下一个出队的位置
+	_    [cacheLineSize - 8]byte
+	mask uint64
+	data []ringCell[T]
+}
+
+// NewLockFreeRingQueue 创建一个无锁环形队列.
+// capacity 必须大于0 否则会 panic.
+// 实际容量会向上取整为2的幂.
+func NewLockFreeRingQueue[T any](capacity int) *LockFreeRingQueue[T] {
+	if capacity < 1 {
+		panic("ukit: 队列容量必须为正数")
+	}
+	realCap := uint64(1)
+	for realCap < uint64(capacity) {
+		realCap <<= 1
+	}
+	q := &LockFreeRingQueue[T]{
+		mask: realCap - 1,
+		data: make([]ringCell[T], realCap),
+	}
+	for i := range q.data {
+		q.data[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Enqueue 入队.
+// 如果队列已满则返回 ErrFullQueue 错误.
+func (q *LockFreeRingQueue[T]) Enqueue(val T) error {
+	pos := q.tail.Load()
+	var cell *ringCell[T]
+	for {
+		cell = &q.data[pos&q.mask]
+		dif := int64(cell.seq.Load() - pos)
+		switch {
+		case dif == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				cell.val = val
+				cell.seq.Store(pos + 1)
+				return nil
+			}
+			pos = q.tail.Load()
+		case dif < 0:
+			// 该槽位上一轮的元素还没有被取走
+			return ErrFullQueue
+		default:
+			// 其他生产者已经占用了该位置
+			pos = q.tail.Load()
+		}
+	}
+}
+
+// Dequeue 出队.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (q *LockFreeRingQueue[T]) Dequeue() (T, error) {
+	pos := q.head.Load()
+	var cell *ringCell[T]
+	for {
+		cell = &q.data[pos&q.mask]
+		dif := int64(cell.seq.Load() - (pos + 1))
+		switch {
+		case dif == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				val := cell.val
+				var t T
+				cell.val = t // 避免持有已出队元素的引用
+				cell.seq.Store(pos + q.mask + 1)
+				return val, nil
+			}
+			pos = q.head.Load()
+		case dif < 0:
+			// 该槽位还没有被写入
+			var t T
+			return t, ErrEmptyQueue
+		default:
+			// 其他消费者已经取走了该位置的元素
+			pos = q.head.Load()
+		}
+	}
+}
+
+// Cap 返回队列的容量.
+func (q *LockFreeRingQueue[T]) Cap() int {
+	return len(q.data)
+}
+
+// Drain 不断地出队并产生出队的元素, 直到队列为空或者停止迭代.
+func (q *LockFreeRingQueue[T]) Drain() iterx.Seq[T] {
+	return drain[T](q)
+}
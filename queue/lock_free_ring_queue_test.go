@@ -0,0 +1,186 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLockFreeRingQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		wantCap   int
+		wantPanic bool
+	}{
+		{
+			name:     "power_of_two",
+			capacity: 4,
+			wantCap:  4,
+		},
+		{
+			name:     "round_up",
+			capacity: 5,
+			wantCap:  8,
+		},
+		{
+			name:     "one",
+			capacity: 1,
+			wantCap:  1,
+		},
+		{
+			name:      "capacity_less_than_1",
+			capacity:  0,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPanic {
+				assert.Panics(t, func() { NewLockFreeRingQueue[int](tt.capacity) })
+				return
+			}
+			assert.Equal(t, tt.wantCap, NewLockFreeRingQueue[int](tt.capacity).Cap())
+		})
+	}
+}
+
+func TestLockFreeRingQueue_EnqueueDequeue(t *testing.T) {
+	q := NewLockFreeRingQueue[int](2)
+	_, err := q.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+
+	// 多绕几圈以覆盖 seq 的回绕
+	for round := 0; round < 3; round++ {
+		assert.NoError(t, q.Enqueue(1))
+		assert.NoError(t, q.Enqueue(2))
+		assert.Equal(t, ErrFullQueue, q.Enqueue(3))
+
+		got, err := q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+		got, err = q.Dequeue()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, got)
+
+		got, err = q.Dequeue()
+		assert.Equal(t, ErrEmptyQueue, err)
+		assert.Equal(t, 0, got)
+	}
+}
+
+func TestLockFreeRingQueue_Concurrent(t *testing.T) {
+	testLockFreeQueueConcurrent(t, NewLockFreeRingQueue[int](64))
+}
+
+// testLockFreeQueueConcurrent 多个生产者和消费者并发操作队列,
+// 检查每个元素都恰好出队一次, 且同一个生产者的元素保持先进先出.
+// 需要使用 -race 运行以检查数据竞争.
+func testLockFreeQueueConcurrent(t *testing.T, q Queue[int]) {
+	const (
+		producers = 4
+		consumers = 4
+		n         = 2000
+	)
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; {
+				if q.Enqueue(p*n+i) == nil {
+					i++
+					continue
+				}
+				runtime.Gosched()
+			}
+		}(p)
+	}
+
+	results := make([][]int, consumers)
+	var received sync.WaitGroup
+	received.Add(producers * n)
+	done := make(chan struct{})
+	var consumerWg sync.WaitGroup
+	consumerWg.Add(consumers)
+	for c := 0; c < consumers; c++ {
+		go func(c int) {
+			defer consumerWg.Done()
+			for {
+				val, err := q.Dequeue()
+				if err == nil {
+					results[c] = append(results[c], val)
+					received.Done()
+					continue
+				}
+				select {
+				case <-done:
+					return
+				default:
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	received.Wait()
+	close(done)
+	consumerWg.Wait()
+
+	seen := make(map[int]struct{}, producers*n)
+	for _, res := range results {
+		last := make([]int, producers)
+		for i := range last {
+			last[i] = -1
+		}
+		for _, val := range res {
+			_, ok := seen[val]
+			assert.False(t, ok, "重复出队 %d", val)
+			seen[val] = struct{}{}
+			p, i := val/n, val%n
+			assert.Greater(t, i, last[p], "生产者 %d 的元素乱序", p)
+			last[p] = i
+		}
+	}
+	assert.Equal(t, producers*n, len(seen))
+}
+
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/queue
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkLockFreeRingQueue   26532566   39.63 ns/op   0 B/op   0 allocs/op
+// BenchmarkBufferedChannel      21995367   61.25 ns/op   0 B/op   0 allocs/op
+func BenchmarkLockFreeRingQueue(b *testing.B) {
+	q := NewLockFreeRingQueue[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if q.Enqueue(1) != nil {
+				_, _ = q.Dequeue()
+			}
+			_, _ = q.Dequeue()
+		}
+	})
+}
+
+func BenchmarkBufferedChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			select {
+			case ch <- 1:
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+			}
+			select {
+			case <-ch:
+			default:
+			}
+		}
+	})
+}
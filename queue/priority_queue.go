@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"golang.org/x/exp/constraints"
+
+	"github.com/udugong/ukit/heap"
+	"github.com/udugong/ukit/iterx"
+)
+
+// PriorityQueue 基于堆实现的优先队列.
+// 每次出队的都是根据 less 比较最小的元素.
+type PriorityQueue[T any] struct {
+	capacity int // 容量, 小于等于0表示无界
+	data     *priorityHeap[T]
+}
+
+// NewPriorityQueue 创建一个优先队列, 元素越小优先级越高.
+// capacity 小于等于0时队列无界.
+func NewPriorityQueue[T constraints.Ordered](capacity int) *PriorityQueue[T] {
+	return NewPriorityQueueFunc[T](capacity, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// NewPriorityQueueFunc 创建一个使用 less 比较优先级的优先队列.
+// less(a, b) 返回 true 表示 a 比 b 先出队.
+// capacity 小于等于0时队列无界.
+func NewPriorityQueueFunc[T any](capacity int, less func(a, b T) bool) *PriorityQueue[T] {
+	initCap := capacity
+	if initCap <= 0 {
+		initCap = 8
+	}
+	return &PriorityQueue[T]{
+		capacity: capacity,
+		data: &priorityHeap[T]{
+			data: make([]T, 0, initCap),
+			less: less,
+		},
+	}
+}
+
+// Enqueue 入队.
+// 如果队列有界且已满则返回 ErrFullQueue 错误.
+func (p *PriorityQueue[T]) Enqueue(val T) error {
+	if p.IsFull() {
+		return ErrFullQueue
+	}
+	heap.Push[T](p.data, val)
+	return nil
+}
+
+// Dequeue 取出优先级最高的元素.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (p *PriorityQueue[T]) Dequeue() (T, error) {
+	if p.IsEmpty() {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	return heap.Pop[T](p.data), nil
+}
+
+// Peek 查看优先级最高的元素.
+// 如果队列为空则返回 ErrEmptyQueue 错误.
+func (p *PriorityQueue[T]) Peek() (T, error) {
+	if p.IsEmpty() {
+		var t T
+		return t, ErrEmptyQueue
+	}
+	return p.data.data[0], nil
+}
+
+// Len 返回队列中元素的个数.
+func (p *PriorityQueue[T]) Len() int {
+	return p.data.Len()
+}
+
+// Cap 返回队列的容量, 小于等于0表示无界.
+func (p *PriorityQueue[T]) Cap() int {
+	return p.capacity
+}
+
+// IsEmpty 队列为空.
+func (p *PriorityQueue[T]) IsEmpty() bool {
+	return p.data.Len() == 0
+}
+
+// IsFull 队列已满.
+// 无界队列永远不会满.
+func (p *PriorityQueue[T]) IsFull() bool {
+	return p.capacity > 0 && p.data.Len() >= p.capacity
+}
+
+// priorityHeap 实现 heap.Interface.
+type priorityHeap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.data) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.less(h.data[i], h.data[j]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.data[i], h.data[j] = h.data[j], h.data[i] }
+
+// Push add x as element Len().
+func (h *priorityHeap[T]) Push(x T) {
+	h.data = append(h.data, x)
+}
+
+// Pop remove and return element Len() - 1.
+func (h *priorityHeap[T]) Pop() T {
+	n := len(h.data) - 1
+	x := h.data[n]
+	var t T
+	h.data[n] = t // 避免持有已出队元素的引用
+	h.data = h.data[:n]
+	return x
+}
+
+// All 按照出队的顺序迭代队列中的元素, 不会修改队列.
+// 迭代时会复制一份堆, 每次产生一个元素的时间复杂度为 O(log n).
+// 迭代过程中不能修改队列.
+func (p *PriorityQueue[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &priorityHeap[T]{
+			data: append([]T(nil), p.data.data...),
+			less: p.data.less,
+		}
+		for h.Len() > 0 {
+			if !yield(heap.Pop[T](h)) {
+				return
+			}
+		}
+	}
+}
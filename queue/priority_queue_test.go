@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue_Enqueue(t *testing.T) {
+	type testCase[T any] struct {
+		name    string
+		pq      func() *PriorityQueue[T]
+		val     T
+		wantErr error
+		wantLen int
+	}
+	tests := []testCase[int]{
+		{
+			name: "normal",
+			pq: func() *PriorityQueue[int] {
+				return NewPriorityQueue[int](1)
+			},
+			val:     1,
+			wantLen: 1,
+		},
+		{
+			name: "queue_is_full",
+			pq: func() *PriorityQueue[int] {
+				pq := NewPriorityQueue[int](1)
+				_ = pq.Enqueue(1)
+				return pq
+			},
+			val:     2,
+			wantErr: ErrFullQueue,
+			wantLen: 1,
+		},
+		{
+			name: "unbounded",
+			pq: func() *PriorityQueue[int] {
+				pq := NewPriorityQueue[int](0)
+				for i := 0; i < 100; i++ {
+					_ = pq.Enqueue(i)
+				}
+				return pq
+			},
+			val:     100,
+			wantLen: 101,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := tt.pq()
+			assert.Equal(t, tt.wantErr, pq.Enqueue(tt.val))
+			assert.Equal(t, tt.wantLen, pq.Len())
+		})
+	}
+}
+
+func TestPriorityQueue_Dequeue(t *testing.T) {
+	pq := NewPriorityQueue[int](0)
+	_, err := pq.Dequeue()
+	assert.Equal(t, ErrEmptyQueue, err)
+
+	for _, val := range []int{5, 1, 4, 2, 3, 1} {
+		_ = pq.Enqueue(val)
+	}
+	var got []int
+	for !pq.IsEmpty() {
+		val, err := pq.Dequeue()
+		assert.NoError(t, err)
+		got = append(got, val)
+	}
+	assert.Equal(t, []int{1, 1, 2, 3, 4, 5}, got)
+}
+
+func TestPriorityQueue_Peek(t *testing.T) {
+	pq := NewPriorityQueue[int](0)
+	_, err := pq.Peek()
+	assert.Equal(t, ErrEmptyQueue, err)
+
+	_ = pq.Enqueue(3)
+	_ = pq.Enqueue(1)
+	_ = pq.Enqueue(2)
+	got, err := pq.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+	assert.Equal(t, 3, pq.Len())
+}
+
+func TestNewPriorityQueueFunc(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	// 大顶堆: priority 越大越先出队
+	pq := NewPriorityQueueFunc[task](3, func(a, b task) bool {
+		return a.priority > b.priority
+	})
+	assert.Equal(t, 3, pq.Cap())
+	_ = pq.Enqueue(task{name: "low", priority: 1})
+	_ = pq.Enqueue(task{name: "high", priority: 9})
+	_ = pq.Enqueue(task{name: "mid", priority: 5})
+	assert.True(t, pq.IsFull())
+	assert.Equal(t, ErrFullQueue, pq.Enqueue(task{name: "extra"}))
+
+	var got []string
+	for !pq.IsEmpty() {
+		val, _ := pq.Dequeue()
+		got = append(got, val.name)
+	}
+	assert.Equal(t, []string{"high", "mid", "low"}, got)
+}
+
+func TestPriorityQueue_DequeueClearsReference(t *testing.T) {
+	pq := NewPriorityQueueFunc[*int](0, func(a, b *int) bool {
+		return *a < *b
+	})
+	a, b := 1, 2
+	_ = pq.Enqueue(&a)
+	_ = pq.Enqueue(&b)
+	_, _ = pq.Dequeue()
+	// 出队后底层数组不应该继续持有元素的引用
+	assert.Nil(t, pq.data.data[:2][1])
+}
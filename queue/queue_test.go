@@ -0,0 +1,184 @@
+package queue_test
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/iterx"
+	"github.com/udugong/ukit/queue"
+	"github.com/udugong/ukit/queue/queuetest"
+)
+
+// TestQueueConformance 所有 Queue 的实现都应该满足相同的行为.
+func TestQueueConformance(t *testing.T) {
+	genInt := func(r *rand.Rand) int { return r.Int() }
+	t.Run("circular_queue", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[int] { return queue.NewCircularQueue[int](4) }, 4, genInt)
+	})
+	t.Run("linked_queue", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[int] { return queue.NewLinkedQueue[int]() }, 0, genInt)
+	})
+	t.Run("linked_queue_string", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[string] { return queue.NewLinkedQueue[string]() }, 0,
+			func(r *rand.Rand) string { return strconv.Itoa(r.Int()) })
+	})
+	t.Run("concurrent_linked_queue", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[int] { return queue.NewConcurrentLinkedQueue[int]() }, 0, genInt)
+	})
+	t.Run("lock_free_ring_queue", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[int] { return queue.NewLockFreeRingQueue[int](4) }, 4, genInt)
+	})
+	t.Run("lock_free_linked_queue", func(t *testing.T) {
+		queuetest.Run(t, func() queue.Queue[int] { return queue.NewLockFreeLinkedQueue[int]() }, 0, genInt)
+	})
+}
+
+// TestPriorityQueueConformance 优先队列每次取出最小的元素.
+func TestPriorityQueueConformance(t *testing.T) {
+	// 取值范围较小以便覆盖相等的元素
+	genInt := func(r *rand.Rand) int { return r.Intn(32) }
+	lessInt := func(a, b int) bool { return a < b }
+	t.Run("bounded", func(t *testing.T) {
+		queuetest.RunPriority(t, func() queue.Queue[int] { return queue.NewPriorityQueue[int](4) }, 4, genInt, lessInt)
+	})
+	t.Run("unbounded", func(t *testing.T) {
+		queuetest.RunPriority(t, func() queue.Queue[int] { return queue.NewPriorityQueue[int](0) }, 0, genInt, lessInt)
+	})
+	t.Run("func", func(t *testing.T) {
+		type task struct {
+			priority int
+			name     string
+		}
+		less := func(a, b task) bool { return a.priority < b.priority }
+		queuetest.RunPriority(t, func() queue.Queue[task] { return queue.NewPriorityQueueFunc[task](0, less) }, 0,
+			func(r *rand.Rand) task {
+				n := r.Intn(32)
+				return task{priority: n, name: strconv.Itoa(n)}
+			}, less)
+	})
+}
+
+// TestAll All 按照出队的顺序迭代元素, 并且不会修改队列.
+func TestAll(t *testing.T) {
+	vals := []int{5, 3, 1, 4}
+	tests := []struct {
+		name     string
+		newQueue func() (queue.Queue[int], iterx.Seq[int])
+		want     []int
+	}{
+		{
+			name: "circular_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewCircularQueue[int](4)
+				return q, q.All()
+			},
+			want: vals,
+		},
+		{
+			name: "linked_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewLinkedQueue[int]()
+				return q, q.All()
+			},
+			want: vals,
+		},
+		{
+			name: "concurrent_linked_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewConcurrentLinkedQueue[int]()
+				return q, q.All()
+			},
+			want: vals,
+		},
+		{
+			name: "concurrent_blocking_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewConcurrentBlockingQueue[int](4)
+				return blockingAdapter{q}, q.All()
+			},
+			want: vals,
+		},
+		{
+			name: "priority_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewPriorityQueue[int](0)
+				return q, q.All()
+			},
+			want: []int{1, 3, 4, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, all := tt.newQueue()
+			// 先出队一个元素, 覆盖循环队列绕回的情况
+			require.NoError(t, q.Enqueue(0))
+			_, err := q.Dequeue()
+			require.NoError(t, err)
+			for _, v := range vals {
+				require.NoError(t, q.Enqueue(v))
+			}
+			assert.Equal(t, tt.want, iterx.Collect(all))
+			assert.Equal(t, tt.want[:2], iterx.Collect(iterx.Take(all, 2)))
+
+			// 迭代不会修改队列
+			for _, want := range tt.want {
+				got, err := q.Dequeue()
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+			assert.Equal(t, []int{}, iterx.Collect(all))
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name     string
+		newQueue func() (queue.Queue[int], iterx.Seq[int])
+	}{
+		{
+			name: "lock_free_ring_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewLockFreeRingQueue[int](8)
+				return q, q.Drain()
+			},
+		},
+		{
+			name: "lock_free_linked_queue",
+			newQueue: func() (queue.Queue[int], iterx.Seq[int]) {
+				q := queue.NewLockFreeLinkedQueue[int]()
+				return q, q.Drain()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, drain := tt.newQueue()
+			for i := 0; i < 5; i++ {
+				require.NoError(t, q.Enqueue(i))
+			}
+			assert.Equal(t, []int{0, 1}, iterx.Collect(iterx.Take(drain, 2)))
+			assert.Equal(t, []int{2, 3, 4}, iterx.Collect(drain))
+			_, err := q.Dequeue()
+			assert.Equal(t, queue.ErrEmptyQueue, err)
+		})
+	}
+}
+
+// blockingAdapter 将 ConcurrentBlockingQueue 适配为 Queue, 只用于非阻塞的场景.
+type blockingAdapter struct {
+	*queue.ConcurrentBlockingQueue[int]
+}
+
+func (b blockingAdapter) Enqueue(v int) error {
+	return b.ConcurrentBlockingQueue.Enqueue(context.Background(), v)
+}
+
+func (b blockingAdapter) Dequeue() (int, error) {
+	return b.ConcurrentBlockingQueue.Dequeue(context.Background())
+}
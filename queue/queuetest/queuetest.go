@@ -0,0 +1,218 @@
+// Package queuetest 提供 queue.Queue 实现的通用测试套件.
+// 任何 queue.Queue 的实现都可以通过 Run 检查是否满足相同的行为约定.
+package queuetest
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/queue"
+)
+
+// Run 对 newQueue 创建的先进先出队列运行完整的行为测试和基于性质的随机测试.
+// newQueue 每次调用都必须返回一个新的空队列.
+// capacity 为队列的容量, 0 表示无界队列.
+// gen 用于生成随机元素, 出队的元素使用 assert.Equal 与入队的元素比较.
+func Run[T any](t *testing.T, newQueue func() queue.Queue[T], capacity int, gen func(r *rand.Rand) T) {
+	run[T](t, newQueue, capacity, gen, &fifoModel[T]{}, func(want, got T) bool {
+		return assert.ObjectsAreEqual(want, got)
+	})
+}
+
+// RunPriority 与 Run 相同, 但要求队列每次按照 less 取出最小的元素.
+// 对于 less 认为相等的元素, 出队的顺序不做要求.
+func RunPriority[T any](t *testing.T, newQueue func() queue.Queue[T], capacity int,
+	gen func(r *rand.Rand) T, less func(a, b T) bool) {
+	run[T](t, newQueue, capacity, gen, &minModel[T]{less: less}, func(want, got T) bool {
+		return !less(want, got) && !less(got, want)
+	})
+}
+
+// suite 一次运行中共享的参数.
+type suite[T any] struct {
+	newQueue func() queue.Queue[T]
+	capacity int
+	seed     int64
+	gen      func(r *rand.Rand) T
+	m        model[T]
+	// equal 判断出队的元素是否与参考模型一致
+	equal func(want, got T) bool
+}
+
+func run[T any](t *testing.T, newQueue func() queue.Queue[T], capacity int,
+	gen func(r *rand.Rand) T, m model[T], equal func(want, got T) bool) {
+	s := &suite[T]{
+		newQueue: newQueue,
+		capacity: capacity,
+		seed:     time.Now().UnixNano(),
+		gen:      gen,
+		m:        m,
+		equal:    equal,
+	}
+	t.Logf("seed %d", s.seed)
+	t.Run("dequeue_empty", s.testDequeueEmpty)
+	t.Run("ordered", s.testOrdered)
+	t.Run("interleaved", s.testInterleaved)
+	if capacity > 0 {
+		t.Run("enqueue_full", s.testEnqueueFull)
+	}
+	t.Run("random_ops", s.testRandomOps)
+}
+
+func (s *suite[T]) testDequeueEmpty(t *testing.T) {
+	q := s.newQueue()
+	got, err := q.Dequeue()
+	assert.Equal(t, queue.ErrEmptyQueue, err)
+	var zero T
+	assert.Equal(t, zero, got)
+}
+
+// testOrdered 放满之后全部取出, 出队的顺序与参考模型一致.
+func (s *suite[T]) testOrdered(t *testing.T) {
+	r := rand.New(rand.NewSource(s.seed))
+	q := s.newQueue()
+	s.m.reset()
+	n := s.capacity
+	if n == 0 {
+		n = 100
+	}
+	for i := 0; i < n; i++ {
+		val := s.gen(r)
+		require.NoError(t, q.Enqueue(val))
+		s.m.push(val)
+	}
+	for i := 0; i < n; i++ {
+		got, err := q.Dequeue()
+		require.NoError(t, err)
+		s.requireEqual(t, s.m.pop(), got, "index %d", i)
+	}
+	_, err := q.Dequeue()
+	assert.Equal(t, queue.ErrEmptyQueue, err)
+}
+
+// testInterleaved 交替入队和出队, 队列中的元素个数始终不超过2.
+func (s *suite[T]) testInterleaved(t *testing.T) {
+	r := rand.New(rand.NewSource(s.seed))
+	q := s.newQueue()
+	s.m.reset()
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 2; j++ {
+			val := s.gen(r)
+			require.NoError(t, q.Enqueue(val))
+			s.m.push(val)
+		}
+		for j := 0; j < 2; j++ {
+			got, err := q.Dequeue()
+			require.NoError(t, err)
+			s.requireEqual(t, s.m.pop(), got, "round %d", i)
+		}
+	}
+}
+
+func (s *suite[T]) testEnqueueFull(t *testing.T) {
+	r := rand.New(rand.NewSource(s.seed))
+	q := s.newQueue()
+	s.m.reset()
+	for i := 0; i < s.capacity; i++ {
+		val := s.gen(r)
+		require.NoError(t, q.Enqueue(val))
+		s.m.push(val)
+	}
+	assert.Equal(t, queue.ErrFullQueue, q.Enqueue(s.gen(r)))
+	got, err := q.Dequeue()
+	require.NoError(t, err)
+	s.requireEqual(t, s.m.pop(), got, "")
+	assert.NoError(t, q.Enqueue(s.gen(r)))
+}
+
+// testRandomOps 随机执行入队和出队, 并与参考模型比较结果.
+func (s *suite[T]) testRandomOps(t *testing.T) {
+	const (
+		rounds = 20
+		ops    = 500
+	)
+	r := rand.New(rand.NewSource(s.seed))
+	for round := 0; round < rounds; round++ {
+		q := s.newQueue()
+		s.m.reset()
+		for op := 0; op < ops; op++ {
+			if r.Intn(2) == 0 {
+				val := s.gen(r)
+				err := q.Enqueue(val)
+				if s.capacity > 0 && s.m.len() >= s.capacity {
+					require.Equalf(t, queue.ErrFullQueue, err, "seed %d, round %d, op %d", s.seed, round, op)
+					continue
+				}
+				require.NoErrorf(t, err, "seed %d, round %d, op %d", s.seed, round, op)
+				s.m.push(val)
+				continue
+			}
+			got, err := q.Dequeue()
+			if s.m.len() == 0 {
+				require.Equalf(t, queue.ErrEmptyQueue, err, "seed %d, round %d, op %d", s.seed, round, op)
+				continue
+			}
+			require.NoErrorf(t, err, "seed %d, round %d, op %d", s.seed, round, op)
+			s.requireEqual(t, s.m.pop(), got, "round %d, op %d", round, op)
+		}
+	}
+}
+
+func (s *suite[T]) requireEqual(t *testing.T, want, got T, format string, args ...any) {
+	t.Helper()
+	if !s.equal(want, got) {
+		require.Failf(t, "出队的元素与参考模型不一致", "seed %d, want %#v, got %#v, "+format,
+			append([]any{s.seed, want, got}, args...)...)
+	}
+}
+
+// model 队列的参考模型.
+type model[T any] interface {
+	reset()
+	push(val T)
+	pop() T
+	len() int
+}
+
+// fifoModel 先进先出的参考模型.
+type fifoModel[T any] struct {
+	data []T
+}
+
+func (m *fifoModel[T]) reset()     { m.data = m.data[:0] }
+func (m *fifoModel[T]) push(val T) { m.data = append(m.data, val) }
+func (m *fifoModel[T]) len() int   { return len(m.data) }
+
+func (m *fifoModel[T]) pop() T {
+	val := m.data[0]
+	m.data = m.data[1:]
+	return val
+}
+
+// minModel 每次取出最小元素的参考模型.
+type minModel[T any] struct {
+	data []T // 升序
+	less func(a, b T) bool
+}
+
+func (m *minModel[T]) reset()   { m.data = m.data[:0] }
+func (m *minModel[T]) len() int { return len(m.data) }
+
+func (m *minModel[T]) push(val T) {
+	i := sort.Search(len(m.data), func(i int) bool { return !m.less(m.data[i], val) })
+	var zero T
+	m.data = append(m.data, zero)
+	copy(m.data[i+1:], m.data[i:])
+	m.data[i] = val
+}
+
+func (m *minModel[T]) pop() T {
+	val := m.data[0]
+	m.data = m.data[1:]
+	return val
+}
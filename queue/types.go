@@ -1,5 +1,7 @@
 package queue
 
+import "context"
+
 // Queue 普通队列
 type Queue[T any] interface {
 	// Enqueue 将元素放入队列
@@ -9,3 +11,13 @@ type Queue[T any] interface {
 	// 如果此时队列里面没有元素,那么返回错误
 	Dequeue() (T, error)
 }
+
+// BlockingQueue 阻塞队列
+type BlockingQueue[T any] interface {
+	// Enqueue 将元素放入队列
+	// 如果此时队列已经满了,那么阻塞直到有空位或 ctx 结束
+	Enqueue(ctx context.Context, t T) error
+	// Dequeue 从队首获得一个元素
+	// 如果此时队列里面没有元素,那么阻塞直到有元素或 ctx 结束
+	Dequeue(ctx context.Context) (T, error)
+}
@@ -0,0 +1,140 @@
+package set
+
+// 集合运算同时支持任意的 Set 实现.
+// 当参与运算的集合都是 MapSet 时会直接遍历 map, 避免调用 Keys 产生的内存分配.
+
+// Union 返回 a 和 b 的并集.
+func Union[T comparable](a, b Set[T]) MapSet[T] {
+	if ma, ok := a.(MapSet[T]); ok {
+		if mb, ok := b.(MapSet[T]); ok {
+			res := make(MapSet[T], len(ma)+len(mb))
+			for key := range ma {
+				res[key] = struct{}{}
+			}
+			for key := range mb {
+				res[key] = struct{}{}
+			}
+			return res
+		}
+	}
+	aKeys, bKeys := a.Keys(), b.Keys()
+	res := make(MapSet[T], len(aKeys)+len(bKeys))
+	for _, key := range aKeys {
+		res[key] = struct{}{}
+	}
+	for _, key := range bKeys {
+		res[key] = struct{}{}
+	}
+	return res
+}
+
+// Intersection 返回 a 和 b 的交集.
+func Intersection[T comparable](a, b Set[T]) MapSet[T] {
+	if ma, ok := a.(MapSet[T]); ok {
+		if mb, ok := b.(MapSet[T]); ok {
+			// 遍历较小的集合
+			if len(ma) > len(mb) {
+				ma, mb = mb, ma
+			}
+			res := make(MapSet[T], len(ma))
+			for key := range ma {
+				if _, ok := mb[key]; ok {
+					res[key] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+	res := make(MapSet[T])
+	for _, key := range a.Keys() {
+		if b.Exists(key) {
+			res[key] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Difference 返回 a 和 b 的差集, 即在 a 中但不在 b 中的元素.
+func Difference[T comparable](a, b Set[T]) MapSet[T] {
+	if ma, ok := a.(MapSet[T]); ok {
+		if mb, ok := b.(MapSet[T]); ok {
+			res := make(MapSet[T], len(ma))
+			for key := range ma {
+				if _, ok := mb[key]; !ok {
+					res[key] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+	res := make(MapSet[T])
+	for _, key := range a.Keys() {
+		if !b.Exists(key) {
+			res[key] = struct{}{}
+		}
+	}
+	return res
+}
+
+// SymmetricDifference 返回 a 和 b 的对称差集, 即只在其中一个集合中的元素.
+func SymmetricDifference[T comparable](a, b Set[T]) MapSet[T] {
+	res := Difference(a, b)
+	if mb, ok := b.(MapSet[T]); ok {
+		if ma, ok := a.(MapSet[T]); ok {
+			for key := range mb {
+				if _, ok := ma[key]; !ok {
+					res[key] = struct{}{}
+				}
+			}
+			return res
+		}
+	}
+	for _, key := range b.Keys() {
+		if !a.Exists(key) {
+			res[key] = struct{}{}
+		}
+	}
+	return res
+}
+
+// IsSubset 返回 a 是否是 b 的子集.
+func IsSubset[T comparable](a, b Set[T]) bool {
+	if ma, ok := a.(MapSet[T]); ok {
+		if mb, ok := b.(MapSet[T]); ok {
+			if len(ma) > len(mb) {
+				return false
+			}
+			for key := range ma {
+				if _, ok := mb[key]; !ok {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	for _, key := range a.Keys() {
+		if !b.Exists(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset 返回 a 是否是 b 的超集.
+func IsSuperset[T comparable](a, b Set[T]) bool {
+	return IsSubset(b, a)
+}
+
+// Equal 返回 a 和 b 是否包含相同的元素.
+func Equal[T comparable](a, b Set[T]) bool {
+	return length(a) == length(b) && IsSubset(a, b)
+}
+
+// length 返回集合中元素的个数.
+// 如果集合实现了 Len 方法则直接使用, 否则通过 Keys 计算.
+func length[T comparable](s Set[T]) int {
+	if l, ok := s.(interface{ Len() int }); ok {
+		return l.Len()
+	}
+	return len(s.Keys())
+}
@@ -0,0 +1,172 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrappedSet 用于覆盖非 MapSet 的通用实现.
+type wrappedSet[T comparable] struct {
+	MapSet[T]
+}
+
+func newWrappedSet[T comparable](keys ...T) wrappedSet[T] {
+	s := wrappedSet[T]{MapSet: make(MapSet[T], len(keys))}
+	for _, key := range keys {
+		s.Add(key)
+	}
+	return s
+}
+
+func newMapSet[T comparable](keys ...T) MapSet[T] {
+	s := make(MapSet[T], len(keys))
+	for _, key := range keys {
+		s.Add(key)
+	}
+	return s
+}
+
+// algebraCases 对每组输入分别使用 MapSet 和通用实现进行测试.
+func algebraCases(a, b []int) map[string][2]Set[int] {
+	return map[string][2]Set[int]{
+		"map_set": {newMapSet(a...), newMapSet(b...)},
+		"generic": {newWrappedSet(a...), newWrappedSet(b...)},
+		"mixed":   {newMapSet(a...), newWrappedSet(b...)},
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []int
+		op     func(a, b Set[int]) MapSet[int]
+		wanted []int
+	}{
+		{
+			name:   "union",
+			a:      []int{1, 2, 3},
+			b:      []int{3, 4},
+			op:     Union[int],
+			wanted: []int{1, 2, 3, 4},
+		},
+		{
+			name:   "union_empty",
+			a:      []int{},
+			b:      []int{1},
+			op:     Union[int],
+			wanted: []int{1},
+		},
+		{
+			name:   "intersection",
+			a:      []int{1, 2, 3},
+			b:      []int{2, 3, 4},
+			op:     Intersection[int],
+			wanted: []int{2, 3},
+		},
+		{
+			name:   "intersection_disjoint",
+			a:      []int{1, 2},
+			b:      []int{3, 4, 5},
+			op:     Intersection[int],
+			wanted: []int{},
+		},
+		{
+			name:   "difference",
+			a:      []int{1, 2, 3},
+			b:      []int{2, 4},
+			op:     Difference[int],
+			wanted: []int{1, 3},
+		},
+		{
+			name:   "symmetric_difference",
+			a:      []int{1, 2, 3},
+			b:      []int{2, 3, 4},
+			op:     SymmetricDifference[int],
+			wanted: []int{1, 4},
+		},
+	}
+	for _, tt := range tests {
+		for name, sets := range algebraCases(tt.a, tt.b) {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				assert.ElementsMatch(t, tt.wanted, tt.op(sets[0], sets[1]).Keys())
+			})
+		}
+	}
+}
+
+func TestSetPredicates(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []int
+		op     func(a, b Set[int]) bool
+		wanted bool
+	}{
+		{
+			name:   "is_subset",
+			a:      []int{1, 2},
+			b:      []int{1, 2, 3},
+			op:     IsSubset[int],
+			wanted: true,
+		},
+		{
+			name:   "not_subset",
+			a:      []int{1, 4},
+			b:      []int{1, 2, 3},
+			op:     IsSubset[int],
+			wanted: false,
+		},
+		{
+			name:   "empty_is_subset",
+			a:      []int{},
+			b:      []int{1},
+			op:     IsSubset[int],
+			wanted: true,
+		},
+		{
+			name:   "is_superset",
+			a:      []int{1, 2, 3},
+			b:      []int{2, 3},
+			op:     IsSuperset[int],
+			wanted: true,
+		},
+		{
+			name:   "not_superset",
+			a:      []int{1, 2},
+			b:      []int{2, 3},
+			op:     IsSuperset[int],
+			wanted: false,
+		},
+		{
+			name:   "equal",
+			a:      []int{1, 2, 3},
+			b:      []int{3, 2, 1},
+			op:     Equal[int],
+			wanted: true,
+		},
+		{
+			name:   "not_equal_subset",
+			a:      []int{1, 2},
+			b:      []int{1, 2, 3},
+			op:     Equal[int],
+			wanted: false,
+		},
+	}
+	for _, tt := range tests {
+		for name, sets := range algebraCases(tt.a, tt.b) {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				assert.Equal(t, tt.wanted, tt.op(sets[0], sets[1]))
+			})
+		}
+	}
+}
+
+func TestSetOperationsDoNotModifyInput(t *testing.T) {
+	a, b := newMapSet(1, 2), newMapSet(2, 3)
+	_ = Union[int](a, b)
+	_ = Intersection[int](a, b)
+	_ = Difference[int](a, b)
+	_ = SymmetricDifference[int](a, b)
+	assert.Equal(t, newMapSet(1, 2), a)
+	assert.Equal(t, newMapSet(2, 3), b)
+}
@@ -0,0 +1,239 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+const wordSize = 64
+
+var errInvalidBitSetData = errors.New("ukit: 非法的 BitSet 数据")
+
+// BitSet 位图, 适用于取值范围比较密集的非负整数集合.
+// 每个整数只占用 1 bit, 设置超出当前范围的位时会自动扩容.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet 创建一个位图, length 为预分配的位数.
+func NewBitSet(length uint) *BitSet {
+	return &BitSet{
+		words: make([]uint64, (length+wordSize-1)/wordSize),
+	}
+}
+
+// Set 将第 i 位设置为1.
+func (b *BitSet) Set(i uint) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		b.grow(w + 1)
+	}
+	b.words[w] |= 1 << (i % wordSize)
+}
+
+// Clear 将第 i 位设置为0.
+func (b *BitSet) Clear(i uint) {
+	w := i / wordSize
+	if w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordSize)
+	}
+}
+
+// Test 返回第 i 位是否为1.
+func (b *BitSet) Test(i uint) bool {
+	w := i / wordSize
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordSize)) != 0
+}
+
+// Count 返回为1的位数.
+func (b *BitSet) Count() int {
+	n := 0
+	for _, word := range b.words {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// NextSet 返回从 i 开始(包含 i)的第一个为1的位.
+// 如果不存在则返回 false.
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	w := i / wordSize
+	if w >= uint(len(b.words)) {
+		return 0, false
+	}
+	word := b.words[w] >> (i % wordSize)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < uint(len(b.words)); w++ {
+		if b.words[w] != 0 {
+			return w*wordSize + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// And 返回 b 和 other 的交集.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	n := len(b.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	res := &BitSet{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		res.words[i] = b.words[i] & other.words[i]
+	}
+	return res
+}
+
+// Or 返回 b 和 other 的并集.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	res := &BitSet{words: make([]uint64, len(long))}
+	copy(res.words, long)
+	for i, word := range short {
+		res.words[i] |= word
+	}
+	return res
+}
+
+// Xor 返回 b 和 other 的对称差集.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	res := &BitSet{words: make([]uint64, len(long))}
+	copy(res.words, long)
+	for i, word := range short {
+		res.words[i] ^= word
+	}
+	return res
+}
+
+// AndNot 返回在 b 中但不在 other 中的位.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	res := &BitSet{words: make([]uint64, len(b.words))}
+	copy(res.words, b.words)
+	for i := 0; i < len(res.words) && i < len(other.words); i++ {
+		res.words[i] &^= other.words[i]
+	}
+	return res
+}
+
+// Equal 返回 b 和 other 是否包含相同的位.
+// 末尾多出来的0不影响比较结果.
+func (b *BitSet) Equal(other *BitSet) bool {
+	long, short := b.words, other.words
+	if len(long) < len(short) {
+		long, short = short, long
+	}
+	for i, word := range short {
+		if long[i] != word {
+			return false
+		}
+	}
+	for _, word := range long[len(short):] {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler.
+// 格式为大端序的 uint64 字数量, 后面跟着每个字的大端序表示.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8+8*len(b.words))
+	binary.BigEndian.PutUint64(data, uint64(len(b.words)))
+	for i, word := range b.words {
+		binary.BigEndian.PutUint64(data[8+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return errInvalidBitSetData
+	}
+	n := binary.BigEndian.Uint64(data)
+	if uint64(len(data)-8)/8 != n || (len(data)-8)%8 != 0 {
+		return errInvalidBitSetData
+	}
+	words := make([]uint64, n)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[8+8*i:])
+	}
+	b.words = words
+	return nil
+}
+
+// AsSet 返回一个以 b 为底层存储的 Set[uint].
+// 对返回值的修改会直接反映到 b 上.
+func (b *BitSet) AsSet() Set[uint] {
+	return bitSetAdapter{b: b}
+}
+
+// grow 扩容到至少 n 个字.
+func (b *BitSet) grow(n uint) {
+	if n <= uint(cap(b.words)) {
+		b.words = b.words[:n]
+		return
+	}
+	newCap := 2 * uint(cap(b.words))
+	if newCap < n {
+		newCap = n
+	}
+	words := make([]uint64, n, newCap)
+	copy(words, b.words)
+	b.words = words
+}
+
+// bitSetAdapter 将 BitSet 适配为 Set[uint].
+type bitSetAdapter struct {
+	b *BitSet
+}
+
+func (a bitSetAdapter) Add(key uint) {
+	a.b.Set(key)
+}
+
+func (a bitSetAdapter) Delete(key uint) {
+	a.b.Clear(key)
+}
+
+func (a bitSetAdapter) Exists(key uint) bool {
+	return a.b.Test(key)
+}
+
+// Keys 按照升序返回所有为1的位.
+func (a bitSetAdapter) Keys() []uint {
+	ans := make([]uint, 0, a.b.Count())
+	for i, ok := a.b.NextSet(0); ok; i, ok = a.b.NextSet(i + 1) {
+		ans = append(ans, i)
+	}
+	return ans
+}
+
+// Len 返回集合中元素的个数.
+func (a bitSetAdapter) Len() int {
+	return a.b.Count()
+}
+
+// All 按照升序迭代所有为1的位. 迭代过程中不能修改位集合.
+func (b *BitSet) All() iterx.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
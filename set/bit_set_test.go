@@ -0,0 +1,165 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBitSet(keys ...uint) *BitSet {
+	b := NewBitSet(0)
+	for _, key := range keys {
+		b.Set(key)
+	}
+	return b
+}
+
+func TestBitSet_SetClearTest(t *testing.T) {
+	b := NewBitSet(10)
+	assert.Equal(t, 1, len(b.words))
+	b.Set(1)
+	b.Set(64)
+	b.Set(200) // 自动扩容
+	assert.Equal(t, 4, len(b.words))
+	assert.True(t, b.Test(1))
+	assert.True(t, b.Test(64))
+	assert.True(t, b.Test(200))
+	assert.False(t, b.Test(2))
+	assert.False(t, b.Test(1000))
+	assert.Equal(t, 3, b.Count())
+
+	b.Clear(64)
+	b.Clear(1000) // 超出范围不会有任何影响
+	assert.False(t, b.Test(64))
+	assert.Equal(t, 2, b.Count())
+}
+
+func TestBitSet_NextSet(t *testing.T) {
+	b := newBitSet(3, 64, 130)
+	tests := []struct {
+		name   string
+		i      uint
+		want   uint
+		wantOk bool
+	}{
+		{name: "from_zero", i: 0, want: 3, wantOk: true},
+		{name: "inclusive", i: 3, want: 3, wantOk: true},
+		{name: "next_word", i: 4, want: 64, wantOk: true},
+		{name: "skip_empty_word", i: 65, want: 130, wantOk: true},
+		{name: "none", i: 131, wantOk: false},
+		{name: "out_of_range", i: 1000, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.NextSet(tt.i)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := newBitSet(1, 2, 3, 100)
+	b := newBitSet(2, 3, 4)
+	tests := []struct {
+		name string
+		got  *BitSet
+		want *BitSet
+	}{
+		{name: "and", got: a.And(b), want: newBitSet(2, 3)},
+		{name: "and_reverse", got: b.And(a), want: newBitSet(2, 3)},
+		{name: "or", got: a.Or(b), want: newBitSet(1, 2, 3, 4, 100)},
+		{name: "xor", got: a.Xor(b), want: newBitSet(1, 4, 100)},
+		{name: "and_not", got: a.AndNot(b), want: newBitSet(1, 100)},
+		{name: "and_not_reverse", got: b.AndNot(a), want: newBitSet(4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(tt.got), "want %v, got %v", tt.want.AsSet().Keys(), tt.got.AsSet().Keys())
+		})
+	}
+	// 运算不会修改参数
+	assert.True(t, newBitSet(1, 2, 3, 100).Equal(a))
+	assert.True(t, newBitSet(2, 3, 4).Equal(b))
+}
+
+func TestBitSet_Equal(t *testing.T) {
+	assert.True(t, NewBitSet(1000).Equal(NewBitSet(0)))
+	assert.True(t, newBitSet(1).Equal(func() *BitSet {
+		b := newBitSet(1, 500)
+		b.Clear(500)
+		return b
+	}()))
+	assert.False(t, newBitSet(1).Equal(newBitSet(1, 500)))
+	assert.False(t, newBitSet(1).Equal(newBitSet(2)))
+}
+
+func TestBitSet_MarshalBinary(t *testing.T) {
+	b := newBitSet(0, 63, 64, 1000)
+	data, err := b.MarshalBinary()
+	assert.NoError(t, err)
+
+	got := NewBitSet(0)
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, b.words, got.words)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too_short", data: []byte{0, 1}},
+		{name: "length_mismatch", data: data[:len(data)-8]},
+		{name: "not_aligned", data: data[:len(data)-1]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, errInvalidBitSetData, NewBitSet(0).UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+func TestBitSet_AsSet(t *testing.T) {
+	b := NewBitSet(0)
+	s := b.AsSet()
+	s.Add(5)
+	s.Add(1)
+	s.Add(70)
+	assert.True(t, b.Test(5))
+	assert.Equal(t, []uint{1, 5, 70}, s.Keys())
+	s.Delete(5)
+	assert.False(t, s.Exists(5))
+	assert.Equal(t, 2, length(s))
+}
+
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/set
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkBitSet/bit_set_set     4215367    259.0 ns/op   0 B/op   0 allocs/op
+// BenchmarkBitSet/bit_set_test    10620561   110.9 ns/op   0 B/op   0 allocs/op
+// BenchmarkBitSet/bit_set_clear   4091511    276.3 ns/op   0 B/op   0 allocs/op
+func BenchmarkBitSet(b *testing.B) {
+	const n = 100
+	s := NewBitSet(n)
+	b.Run("bit_set_set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			do(n, func(i int) {
+				s.Set(uint(i))
+			})
+		}
+	})
+	b.Run("bit_set_test", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			do(n, func(i int) {
+				s.Test(uint(i))
+			})
+		}
+	})
+	b.Run("bit_set_clear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			do(n, func(i int) {
+				s.Clear(uint(i))
+			})
+		}
+	})
+}
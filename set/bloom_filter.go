@@ -0,0 +1,165 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+var errInvalidFilterData = errors.New("ukit: 非法的过滤器数据")
+
+// maxBloomHashes 反序列化时允许的哈希函数个数上限.
+// 误判率为 1e-30 时 k 也只有 100 左右, 限制 k 可以避免恶意数据使查询长时间循环.
+const maxBloomHashes = 256
+
+// BloomFilter 布隆过滤器.
+// MayContain 返回 false 时元素一定不存在, 返回 true 时元素可能存在.
+// 可以看作是 Set.Exists 的 "可能存在" 版本, 用于在查询缓存或数据库之前过滤掉不存在的 key.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // 位数
+	k    uint64 // 哈希函数的个数
+}
+
+// NewBloomFilter 根据预计的元素个数 n 和期望的误判率 p 创建布隆过滤器.
+// n 必须大于0, p 必须在 (0, 1) 之间, 否则会 panic.
+func NewBloomFilter(n uint64, p float64) *BloomFilter {
+	if n == 0 {
+		panic("ukit: 预计的元素个数必须为正数")
+	}
+	if p <= 0 || p >= 1 {
+		panic("ukit: 误判率必须在 (0, 1) 之间")
+	}
+	// m = -n*ln(p) / ln(2)^2, k = m/n * ln(2)
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &BloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// Add 添加元素.
+func (b *BloomFilter) Add(key []byte) {
+	h1, h2 := hash128(key)
+	b.set(h1, h2)
+}
+
+// AddString 添加字符串元素.
+func (b *BloomFilter) AddString(key string) {
+	h1, h2 := hash128(key)
+	b.set(h1, h2)
+}
+
+// MayContain 返回元素是否可能存在.
+func (b *BloomFilter) MayContain(key []byte) bool {
+	h1, h2 := hash128(key)
+	return b.test(h1, h2)
+}
+
+// MayContainString 返回字符串元素是否可能存在.
+func (b *BloomFilter) MayContainString(key string) bool {
+	h1, h2 := hash128(key)
+	return b.test(h1, h2)
+}
+
+func (b *BloomFilter) set(h1, h2 uint64) {
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+func (b *BloomFilter) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < b.k; i++ {
+		pos := (h1 + i*h2) % b.m
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// FillRatio 返回为1的位所占的比例.
+func (b *BloomFilter) FillRatio() float64 {
+	n := 0
+	for _, word := range b.bits {
+		n += bits.OnesCount64(word)
+	}
+	return float64(n) / float64(b.m)
+}
+
+// EstimatedFalsePositiveRate 根据当前的填充率估算误判率.
+func (b *BloomFilter) EstimatedFalsePositiveRate() float64 {
+	return math.Pow(b.FillRatio(), float64(b.k))
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler.
+// 格式为大端序的 m 和 k, 后面跟着位数组.
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 16+8*len(b.bits))
+	binary.BigEndian.PutUint64(data, b.m)
+	binary.BigEndian.PutUint64(data[8:], b.k)
+	for i, word := range b.bits {
+		binary.BigEndian.PutUint64(data[16+8*i:], word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return errInvalidFilterData
+	}
+	m := binary.BigEndian.Uint64(data)
+	k := binary.BigEndian.Uint64(data[8:])
+	if k == 0 || k > maxBloomHashes {
+		return errInvalidFilterData
+	}
+	// 位数组占用 bits 位, m 必须落在 (bits-64, bits] 之间. 不计算 (m+63)/64 以免溢出
+	payload := len(data) - 16
+	bits := uint64(payload) * 8
+	if payload == 0 || payload%8 != 0 || m > bits || m <= bits-64 {
+		return errInvalidFilterData
+	}
+	words := make([]uint64, payload/8)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[16+8*i:])
+	}
+	b.bits, b.m, b.k = words, m, k
+	return nil
+}
+
+// hash128 使用 FNV-1a 计算64位哈希值 h1, 再通过 mix64 从 h1 派生出 h2.
+// h2 完全由 h1 决定, 两者并不独立, 只是 mix64 打乱了各个位之间的关系,
+// 用于双重哈希 h1 + i*h2 时足够分散.
+// 哈希结果与进程无关, 序列化后的过滤器可以在其他进程中使用.
+func hash128[K string | []byte](key K) (uint64, uint64) {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= prime64
+	}
+	h2 := mix64(h)
+	// 保证 h2 为奇数, 避免 m 为偶数时只能命中部分位置
+	return h, h2 | 1
+}
+
+// mix64 splitmix64 的混淆函数.
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,120 @@
+package set
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         uint64
+		p         float64
+		wantM     uint64
+		wantK     uint64
+		wantPanic bool
+	}{
+		{
+			name:  "normal",
+			n:     1000,
+			p:     0.01,
+			wantM: 9586,
+			wantK: 7,
+		},
+		{
+			name:      "zero_n",
+			n:         0,
+			p:         0.01,
+			wantPanic: true,
+		},
+		{
+			name:      "invalid_p",
+			n:         1000,
+			p:         1,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPanic {
+				assert.Panics(t, func() { NewBloomFilter(tt.n, tt.p) })
+				return
+			}
+			b := NewBloomFilter(tt.n, tt.p)
+			assert.Equal(t, tt.wantM, b.m)
+			assert.Equal(t, tt.wantK, b.k)
+		})
+	}
+}
+
+func TestBloomFilter_MayContain(t *testing.T) {
+	const n = 10000
+	b := NewBloomFilter(n, 0.01)
+	assert.Equal(t, 0.0, b.FillRatio())
+	for i := 0; i < n; i++ {
+		b.AddString(strconv.Itoa(i))
+	}
+	// 不能漏判
+	for i := 0; i < n; i++ {
+		assert.True(t, b.MayContainString(strconv.Itoa(i)))
+		assert.True(t, b.MayContain([]byte(strconv.Itoa(i))))
+	}
+	// 误判率应该接近期望值
+	falsePositives := 0
+	for i := n; i < 2*n; i++ {
+		if b.MayContainString(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/n, 0.02)
+	// 达到预计元素个数时大约一半的位为1
+	assert.InDelta(t, 0.5, b.FillRatio(), 0.05)
+	assert.InDelta(t, 0.01, b.EstimatedFalsePositiveRate(), 0.005)
+}
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	b := NewBloomFilter(100, 0.01)
+	b.Add([]byte("foo"))
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+
+	got := &BloomFilter{}
+	require.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, b, got)
+	assert.True(t, got.MayContainString("foo"))
+
+	assert.Equal(t, errInvalidFilterData, got.UnmarshalBinary(data[:10]))
+	assert.Equal(t, errInvalidFilterData, got.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestBloomFilter_UnmarshalBinaryInvalid(t *testing.T) {
+	header := func(m, k uint64, words int) []byte {
+		data := make([]byte, 16+8*words)
+		binary.BigEndian.PutUint64(data, m)
+		binary.BigEndian.PutUint64(data[8:], k)
+		return data
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too_short", data: make([]byte, 10)},
+		{name: "zero_m", data: header(0, 3, 1)},
+		{name: "zero_k", data: header(64, 0, 1)},
+		{name: "too_many_hashes", data: header(64, maxBloomHashes+1, 1)},
+		{name: "no_words", data: header(64, 3, 0)},
+		{name: "unaligned", data: header(64, 3, 1)[:23]},
+		{name: "m_too_large", data: header(65, 3, 1)},
+		{name: "m_too_small", data: header(64, 3, 2)},
+		{name: "m_overflow", data: header(1<<64-1, 3, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, errInvalidFilterData, (&BloomFilter{}).UnmarshalBinary(tt.data))
+		})
+	}
+}
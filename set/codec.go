@@ -0,0 +1,233 @@
+package set
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	errTextUnsupported = errors.New("ukit: 集合元素不支持文本序列化")
+	errInvalidText     = errors.New("ukit: 非法的集合文本")
+)
+
+// MarshalJSON 实现 json.Marshaler, 集合会被序列化为 JSON 数组.
+// 如果元素是整数, 浮点数或字符串则按照升序排列, 以保证输出稳定.
+func (s MapSet[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(sortedKeys(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler, 从 JSON 数组中读取元素.
+// 会替换集合中原有的元素.
+func (s *MapSet[T]) UnmarshalJSON(data []byte) error {
+	var keys []T
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	s.reset(keys)
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler, 元素之间使用 "," 分隔.
+// 支持实现了 encoding.TextMarshaler 的元素, 以及底层类型为字符串, 整数, 浮点数或布尔值的元素.
+// 为空字符串, 包含 "," 或者以 `"` 开头的元素会使用 strconv.Quote 加上引号, 所以任意字符串都可以还原.
+// 例如 {"a", "b,c", ""} 会被序列化为 `"",a,"b,c"`.
+func (s MapSet[T]) MarshalText() ([]byte, error) {
+	keys := sortedKeys(s)
+	var sb strings.Builder
+	for i, key := range keys {
+		text, err := formatText(key)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if text == "" || strings.HasPrefix(text, `"`) || strings.Contains(text, ",") {
+			text = strconv.Quote(text)
+		}
+		sb.WriteString(text)
+	}
+	return []byte(sb.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler, 读取 MarshalText 格式的文本.
+// 空文本表示空集合, 没有引号的元素不能为空字符串.
+// 会替换集合中原有的元素.
+func (s *MapSet[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		s.reset(nil)
+		return nil
+	}
+	parts, err := splitText(string(text))
+	if err != nil {
+		return err
+	}
+	keys := make([]T, len(parts))
+	for i, part := range parts {
+		if err := parseText(&keys[i], part); err != nil {
+			return err
+		}
+	}
+	s.reset(keys)
+	return nil
+}
+
+// splitText 将使用 "," 分隔的文本拆分为元素, 以 `"` 开头的元素会被去掉引号.
+func splitText(text string) ([]string, error) {
+	var parts []string
+	for {
+		var part string
+		if strings.HasPrefix(text, `"`) {
+			quoted, err := strconv.QuotedPrefix(text)
+			if err != nil {
+				return nil, errInvalidText
+			}
+			part, _ = strconv.Unquote(quoted)
+			text = text[len(quoted):]
+		} else {
+			end := strings.IndexByte(text, ',')
+			if end < 0 {
+				end = len(text)
+			}
+			part, text = text[:end], text[end:]
+			if part == "" {
+				return nil, errInvalidText
+			}
+		}
+		parts = append(parts, part)
+		if text == "" {
+			return parts, nil
+		}
+		if text[0] != ',' || len(text) == 1 {
+			return nil, errInvalidText
+		}
+		text = text[1:]
+	}
+}
+
+// formatText 返回元素的文本形式.
+func formatText[T comparable](key T) (string, error) {
+	if m, ok := any(key).(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	default:
+		return "", errTextUnsupported
+	}
+}
+
+// parseText 将文本解析到 key 中.
+func parseText[T comparable](key *T, text string) error {
+	if u, ok := any(key).(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(text))
+	}
+	v := reflect.ValueOf(key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return errTextUnsupported
+	}
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用 gob 编码所有元素.
+// gob 会自动使用该方法, 所以 MapSet 可以直接使用 gob 序列化.
+func (s MapSet[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(sortedKeys(s)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+// 会替换集合中原有的元素.
+func (s *MapSet[T]) UnmarshalBinary(data []byte) error {
+	var keys []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+		return err
+	}
+	s.reset(keys)
+	return nil
+}
+
+// reset 使用 keys 替换集合中原有的元素.
+func (s *MapSet[T]) reset(keys []T) {
+	res := make(MapSet[T], len(keys))
+	for _, key := range keys {
+		res[key] = struct{}{}
+	}
+	*s = res
+}
+
+// sortedKeys 返回集合中的元素.
+// 如果元素的底层类型是整数, 浮点数或字符串则按照升序排列.
+func sortedKeys[T comparable](s MapSet[T]) []T {
+	keys := s.Keys()
+	if len(keys) < 2 {
+		return keys
+	}
+	v := reflect.ValueOf(keys)
+	var less func(i, j int) bool
+	switch v.Index(0).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		less = func(i, j int) bool { return v.Index(i).Int() < v.Index(j).Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		less = func(i, j int) bool { return v.Index(i).Uint() < v.Index(j).Uint() }
+	case reflect.Float32, reflect.Float64:
+		less = func(i, j int) bool { return v.Index(i).Float() < v.Index(j).Float() }
+	case reflect.String:
+		less = func(i, j int) bool { return v.Index(i).String() < v.Index(j).String() }
+	default:
+		return keys
+	}
+	sort.Slice(keys, less)
+	return keys
+}
@@ -0,0 +1,207 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapSet_MarshalJSON(t *testing.T) {
+	type userID int64
+	tests := []struct {
+		name string
+		set  any
+		want string
+	}{
+		{
+			name: "int",
+			set:  MapSet[int]{3: {}, 1: {}, 2: {}},
+			want: `[1,2,3]`,
+		},
+		{
+			name: "named_int",
+			set:  MapSet[userID]{30: {}, 10: {}, 20: {}},
+			want: `[10,20,30]`,
+		},
+		{
+			name: "string",
+			set:  MapSet[string]{"b": {}, "a": {}},
+			want: `["a","b"]`,
+		},
+		{
+			name: "float",
+			set:  MapSet[float64]{1.5: {}, -1: {}},
+			want: `[-1,1.5]`,
+		},
+		{
+			name: "empty",
+			set:  MapSet[int]{},
+			want: `[]`,
+		},
+		{
+			name: "nil",
+			set:  MapSet[int](nil),
+			want: `null`,
+		},
+		{
+			name: "field",
+			set: struct {
+				Tags MapSet[string] `json:"tags"`
+			}{Tags: MapSet[string]{"x": {}}},
+			want: `{"tags":["x"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.set)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestMapSet_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    MapSet[int]
+		wantErr bool
+	}{
+		{
+			name: "normal",
+			data: `[1,2,2,3]`,
+			want: MapSet[int]{1: {}, 2: {}, 3: {}},
+		},
+		{
+			name: "empty",
+			data: `[]`,
+			want: MapSet[int]{},
+		},
+		{
+			name:    "object",
+			data:    `{"1":{}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := MapSet[int]{100: {}} // 原有的元素会被替换
+			err := json.Unmarshal([]byte(tt.data), &s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s)
+		})
+	}
+}
+
+func TestMapSet_MarshalText(t *testing.T) {
+	got, err := MapSet[string]{"b": {}, "a": {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "a,b", string(got))
+
+	// 实现了 encoding.TextMarshaler 的元素
+	addrs := MapSet[netip.Addr]{netip.MustParseAddr("127.0.0.1"): {}}
+	got, err = addrs.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", string(got))
+
+	// 数字和布尔值使用 strconv 格式化
+	got, err = MapSet[int]{10: {}, -1: {}, 2: {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "-1,2,10", string(got))
+	got, err = MapSet[float64]{1.5: {}, 0.1: {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "0.1,1.5", string(got))
+	got, err = MapSet[bool]{true: {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "true", string(got))
+
+	_, err = MapSet[[2]int]{{1, 2}: {}}.MarshalText()
+	assert.Equal(t, errTextUnsupported, err)
+
+	// 空字符串, 包含 "," 或者以引号开头的元素会加上引号
+	got, err = MapSet[string]{"": {}, "a": {}, "b,c": {}, `"d`: {}, `e"`: {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, `"","\"d",a,"b,c",e"`, string(got))
+	got, err = MapSet[string]{"": {}}.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, `""`, string(got))
+}
+
+func TestMapSet_UnmarshalText(t *testing.T) {
+	var s MapSet[string]
+	require.NoError(t, s.UnmarshalText([]byte("a,b,a")))
+	assert.Equal(t, MapSet[string]{"a": {}, "b": {}}, s)
+	require.NoError(t, s.UnmarshalText(nil))
+	assert.Equal(t, MapSet[string]{}, s)
+
+	var addrs MapSet[netip.Addr]
+	require.NoError(t, addrs.UnmarshalText([]byte("127.0.0.1,::1")))
+	assert.True(t, addrs.Exists(netip.MustParseAddr("::1")))
+	assert.Error(t, addrs.UnmarshalText([]byte("invalid")))
+
+	var ints MapSet[int8]
+	require.NoError(t, ints.UnmarshalText([]byte("-1,2,10")))
+	assert.Equal(t, MapSet[int8]{-1: {}, 2: {}, 10: {}}, ints)
+	assert.Error(t, ints.UnmarshalText([]byte("128")))
+	var uints MapSet[uint]
+	assert.Error(t, uints.UnmarshalText([]byte("-1")))
+	var floats MapSet[float32]
+	require.NoError(t, floats.UnmarshalText([]byte("0.1,1.5")))
+	assert.Equal(t, MapSet[float32]{0.1: {}, 1.5: {}}, floats)
+
+	require.NoError(t, s.UnmarshalText([]byte(`"a,b",c,"",e"`)))
+	assert.Equal(t, MapSet[string]{"a,b": {}, "c": {}, "": {}, `e"`: {}}, s)
+
+	for _, text := range []string{"a,,b", ",", "a,", `"a`, `"a"b`, `"a",`} {
+		assert.Equal(t, errInvalidText, s.UnmarshalText([]byte(text)), text)
+	}
+	var arrays MapSet[[2]int]
+	assert.Equal(t, errTextUnsupported, arrays.UnmarshalText([]byte("1")))
+}
+
+func TestMapSet_TextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		set  MapSet[string]
+	}{
+		{name: "empty", set: MapSet[string]{}},
+		{name: "single", set: MapSet[string]{"a": {}}},
+		{name: "multiple", set: MapSet[string]{"a": {}, "b": {}, " ": {}}},
+		{name: "empty_element", set: MapSet[string]{"": {}}},
+		{name: "special", set: MapSet[string]{"": {}, ",": {}, `"`: {}, `","`: {}, "a\nb": {}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.set.MarshalText()
+			require.NoError(t, err)
+			var got MapSet[string]
+			require.NoError(t, got.UnmarshalText(text))
+			assert.Equal(t, tt.set, got)
+		})
+	}
+}
+
+func TestMapSet_Gob(t *testing.T) {
+	type payload struct {
+		IDs MapSet[int]
+	}
+	want := payload{IDs: MapSet[int]{1: {}, 2: {}}}
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got payload
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(t, want, got)
+
+	var s MapSet[int]
+	assert.Error(t, s.UnmarshalBinary([]byte("invalid")))
+}
@@ -0,0 +1,80 @@
+package set
+
+import (
+	"sync"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+// ConcurrentSet 使用读写锁保护的并发安全集合.
+type ConcurrentSet[T comparable] struct {
+	mu   sync.RWMutex
+	data MapSet[T]
+}
+
+// NewConcurrentSet 创建一个并发安全的集合.
+func NewConcurrentSet[T comparable](cap int) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{
+		data: New[T](cap),
+	}
+}
+
+func (s *ConcurrentSet[T]) Add(key T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Add(key)
+}
+
+func (s *ConcurrentSet[T]) Delete(key T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Delete(key)
+}
+
+func (s *ConcurrentSet[T]) Exists(key T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.Exists(key)
+}
+
+func (s *ConcurrentSet[T]) Keys() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.Keys()
+}
+
+// Len 返回集合中元素的个数.
+func (s *ConcurrentSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.data)
+}
+
+// AddIfAbsent 如果元素不存在则添加, 返回集合是否发生了变化.
+func (s *ConcurrentSet[T]) AddIfAbsent(key T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data.Exists(key) {
+		return false
+	}
+	s.data.Add(key)
+	return true
+}
+
+// DeleteIfPresent 如果元素存在则删除, 返回集合是否发生了变化.
+func (s *ConcurrentSet[T]) DeleteIfPresent(key T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.data.Exists(key) {
+		return false
+	}
+	s.data.Delete(key)
+	return true
+}
+
+// All 迭代调用时集合中元素的快照, 顺序是不确定的. 迭代过程中可以修改集合.
+func (s *ConcurrentSet[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		iterx.FromSlice(s.Keys())(yield)
+	}
+}
@@ -0,0 +1,102 @@
+package set
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentSet_AddIfAbsent(t *testing.T) {
+	s := NewConcurrentSet[int](0)
+	assert.True(t, s.AddIfAbsent(1))
+	assert.False(t, s.AddIfAbsent(1))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestConcurrentSet_DeleteIfPresent(t *testing.T) {
+	s := NewConcurrentSet[int](0)
+	s.Add(1)
+	assert.True(t, s.DeleteIfPresent(1))
+	assert.False(t, s.DeleteIfPresent(1))
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestConcurrentSet_Concurrent(t *testing.T) {
+	testConcurrentSet(t, NewConcurrentSet[int](0))
+}
+
+// testConcurrentSet 多个 goroutine 同时添加相同的元素,
+// 每个元素都只能有一个 goroutine 的 AddIfAbsent 返回 true, 删除同理.
+// 需要使用 -race 运行以检查数据竞争.
+func testConcurrentSet(t *testing.T, s interface {
+	Set[int]
+	AddIfAbsent(key int) bool
+	DeleteIfPresent(key int) bool
+}) {
+	const (
+		goroutines = 8
+		n          = 500
+	)
+	var added, deleted [n]int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if s.AddIfAbsent(i) {
+					mu.Lock()
+					added[i]++
+					mu.Unlock()
+				}
+				_ = s.Exists(i)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, n, len(s.Keys()))
+
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if s.DeleteIfPresent(i) {
+					mu.Lock()
+					deleted[i]++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Empty(t, s.Keys())
+	for i := 0; i < n; i++ {
+		assert.Equal(t, int32(1), added[i])
+		assert.Equal(t, int32(1), deleted[i])
+	}
+}
+
+func BenchmarkConcurrentSet(b *testing.B) {
+	benchmarkConcurrentSet(b, NewConcurrentSet[int](0))
+}
+
+func benchmarkConcurrentSet(b *testing.B, s Set[int]) {
+	for i := 0; i < 1024; i++ {
+		s.Add(i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			// 读多写少
+			if i%10 == 0 {
+				s.Add(i & 1023)
+			} else {
+				s.Exists(i & 1023)
+			}
+			i++
+		}
+	})
+}
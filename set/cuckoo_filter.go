@@ -0,0 +1,281 @@
+package set
+
+import "encoding/binary"
+
+const (
+	// bucketSize 每个桶的槽位数量.
+	bucketSize = 4
+	// maxKicks 插入时最多踢出的次数.
+	maxKicks = 500
+)
+
+// CuckooFilter 布谷鸟过滤器.
+// 与布隆过滤器相比支持删除元素, 每个元素使用16位的指纹存储.
+// MayContain 返回 false 时元素一定不存在, 返回 true 时元素可能存在.
+type CuckooFilter struct {
+	buckets [][bucketSize]uint16 // 指纹为0表示空槽位
+	mask    uint64
+	count   uint64
+	rnd     uint64 // 用于选择被踢出的槽位
+
+	// victim 插入失败时被踢出的指纹.
+	// 存在 victim 时过滤器已满, 之后的插入都会失败.
+	victim       uint16
+	victimIndex  uint64
+	victimExists bool
+}
+
+// NewCuckooFilter 创建一个至少可以容纳 n 个元素的布谷鸟过滤器.
+// n 必须大于0 否则会 panic.
+func NewCuckooFilter(n uint64) *CuckooFilter {
+	if n == 0 {
+		panic("ukit: 预计的元素个数必须为正数")
+	}
+	// 负载率在 95% 左右时插入开始频繁失败
+	want := (n*100/95 + bucketSize - 1) / bucketSize
+	num := uint64(1)
+	for num < want {
+		num <<= 1
+	}
+	return &CuckooFilter{
+		buckets: make([][bucketSize]uint16, num),
+		mask:    num - 1,
+		rnd:     0x9e3779b97f4a7c15,
+	}
+}
+
+// index 返回元素的指纹和第一个候选桶.
+// FNV-1a 的低位只取决于输入的低位, 直接取模会使只有高位不同的元素落在同一个桶中,
+// 所以先使用 mix64 混淆.
+func (c *CuckooFilter) index(h uint64) (uint16, uint64) {
+	h = mix64(h)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & c.mask
+}
+
+// altIndex 返回另一个候选桶, altIndex(altIndex(i, fp), fp) == i.
+func (c *CuckooFilter) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ mix64(uint64(fp))) & c.mask
+}
+
+// Add 添加元素, 如果过滤器已满则返回 false.
+func (c *CuckooFilter) Add(key []byte) bool {
+	h, _ := hash128(key)
+	return c.add(h)
+}
+
+// AddString 添加字符串元素, 如果过滤器已满则返回 false.
+func (c *CuckooFilter) AddString(key string) bool {
+	h, _ := hash128(key)
+	return c.add(h)
+}
+
+func (c *CuckooFilter) add(h uint64) bool {
+	if c.victimExists {
+		return false
+	}
+	fp, i := c.index(h)
+	if c.insert(i, fp) || c.insert(c.altIndex(i, fp), fp) {
+		c.count++
+		return true
+	}
+	// 随机踢出一个指纹放到它的另一个候选桶中
+	for n := 0; n < maxKicks; n++ {
+		slot := c.random() % bucketSize
+		fp, c.buckets[i][slot] = c.buckets[i][slot], fp
+		i = c.altIndex(i, fp)
+		if c.insert(i, fp) {
+			c.count++
+			return true
+		}
+	}
+	// 新元素已经放入了过滤器, 保存最后被踢出的指纹
+	c.victim, c.victimIndex, c.victimExists = fp, i, true
+	c.count++
+	return true
+}
+
+func (c *CuckooFilter) insert(i uint64, fp uint16) bool {
+	for slot, v := range c.buckets[i] {
+		if v == 0 {
+			c.buckets[i][slot] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// MayContain 返回元素是否可能存在.
+func (c *CuckooFilter) MayContain(key []byte) bool {
+	h, _ := hash128(key)
+	return c.contains(h)
+}
+
+// MayContainString 返回字符串元素是否可能存在.
+func (c *CuckooFilter) MayContainString(key string) bool {
+	h, _ := hash128(key)
+	return c.contains(h)
+}
+
+func (c *CuckooFilter) contains(h uint64) bool {
+	fp, i1 := c.index(h)
+	i2 := c.altIndex(i1, fp)
+	if c.victimExists && c.victim == fp && (c.victimIndex == i1 || c.victimIndex == i2) {
+		return true
+	}
+	for _, v := range c.buckets[i1] {
+		if v == fp {
+			return true
+		}
+	}
+	for _, v := range c.buckets[i2] {
+		if v == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete 删除元素, 如果元素不存在则返回 false.
+// 只能删除已经添加过的元素, 否则可能会误删其他指纹相同的元素.
+func (c *CuckooFilter) Delete(key []byte) bool {
+	h, _ := hash128(key)
+	return c.delete(h)
+}
+
+// DeleteString 删除字符串元素, 如果元素不存在则返回 false.
+func (c *CuckooFilter) DeleteString(key string) bool {
+	h, _ := hash128(key)
+	return c.delete(h)
+}
+
+func (c *CuckooFilter) delete(h uint64) bool {
+	fp, i1 := c.index(h)
+	i2 := c.altIndex(i1, fp)
+	if c.victimExists && c.victim == fp && (c.victimIndex == i1 || c.victimIndex == i2) {
+		c.victimExists = false
+		c.count--
+		return true
+	}
+	for _, i := range [2]uint64{i1, i2} {
+		for slot, v := range c.buckets[i] {
+			if v == fp {
+				c.buckets[i][slot] = 0
+				c.count--
+				c.reinsertVictim()
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reinsertVictim 删除元素后尝试把 victim 放回桶中.
+func (c *CuckooFilter) reinsertVictim() {
+	if !c.victimExists {
+		return
+	}
+	c.victimExists = false
+	c.count--
+	h := c.victimIndex
+	fp := c.victim
+	if c.insert(h, fp) || c.insert(c.altIndex(h, fp), fp) {
+		c.count++
+		return
+	}
+	c.victimExists = true
+	c.count++
+}
+
+// Count 返回过滤器中元素的个数.
+func (c *CuckooFilter) Count() uint64 {
+	return c.count
+}
+
+// FillRatio 返回已使用的槽位所占的比例.
+func (c *CuckooFilter) FillRatio() float64 {
+	return float64(c.count) / float64(len(c.buckets)*bucketSize)
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler.
+// 格式为大端序的桶数量, 元素个数, victim 信息, 后面跟着所有的指纹.
+func (c *CuckooFilter) MarshalBinary() ([]byte, error) {
+	const headerSize = 8 + 8 + 1 + 2 + 8
+	data := make([]byte, headerSize+2*bucketSize*len(c.buckets))
+	binary.BigEndian.PutUint64(data, uint64(len(c.buckets)))
+	binary.BigEndian.PutUint64(data[8:], c.count)
+	if c.victimExists {
+		data[16] = 1
+	}
+	binary.BigEndian.PutUint16(data[17:], c.victim)
+	binary.BigEndian.PutUint64(data[19:], c.victimIndex)
+	pos := headerSize
+	for _, bucket := range c.buckets {
+		for _, fp := range bucket {
+			binary.BigEndian.PutUint16(data[pos:], fp)
+			pos += 2
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+func (c *CuckooFilter) UnmarshalBinary(data []byte) error {
+	const headerSize = 8 + 8 + 1 + 2 + 8
+	if len(data) < headerSize {
+		return errInvalidFilterData
+	}
+	num := binary.BigEndian.Uint64(data)
+	// 先用除法检查桶数量, 避免 num*2*bucketSize 溢出
+	payload := uint64(len(data) - headerSize)
+	if num == 0 || num&(num-1) != 0 || num > payload/(2*bucketSize) || payload != num*2*bucketSize {
+		return errInvalidFilterData
+	}
+	if data[16] > 1 {
+		return errInvalidFilterData
+	}
+	res := CuckooFilter{
+		buckets:      make([][bucketSize]uint16, num),
+		mask:         num - 1,
+		count:        binary.BigEndian.Uint64(data[8:]),
+		rnd:          0x9e3779b97f4a7c15,
+		victimExists: data[16] == 1,
+		victim:       binary.BigEndian.Uint16(data[17:]),
+		victimIndex:  binary.BigEndian.Uint64(data[19:]),
+	}
+	if res.victimIndex > res.mask || res.victimExists && res.victim == 0 {
+		return errInvalidFilterData
+	}
+	// 元素个数等于非空槽位的个数加上 victim
+	used := uint64(0)
+	if res.victimExists {
+		used++
+	}
+	pos := headerSize
+	for i := range res.buckets {
+		for slot := range res.buckets[i] {
+			fp := binary.BigEndian.Uint16(data[pos:])
+			res.buckets[i][slot] = fp
+			if fp != 0 {
+				used++
+			}
+			pos += 2
+		}
+	}
+	if res.count != used {
+		return errInvalidFilterData
+	}
+	*c = res
+	return nil
+}
+
+// random xorshift64 伪随机数.
+func (c *CuckooFilter) random() uint64 {
+	c.rnd ^= c.rnd << 13
+	c.rnd ^= c.rnd >> 7
+	c.rnd ^= c.rnd << 17
+	return c.rnd
+}
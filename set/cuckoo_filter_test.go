@@ -0,0 +1,140 @@
+package set
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCuckooFilter(t *testing.T) {
+	assert.Equal(t, 512, len(NewCuckooFilter(1000).buckets))
+	assert.Equal(t, 1, len(NewCuckooFilter(1).buckets))
+	assert.Panics(t, func() { NewCuckooFilter(0) })
+}
+
+func TestCuckooFilter_AddDelete(t *testing.T) {
+	const n = 10000
+	c := NewCuckooFilter(n)
+	for i := 0; i < n; i++ {
+		require.True(t, c.AddString(strconv.Itoa(i)))
+	}
+	assert.Equal(t, uint64(n), c.Count())
+	// 不能漏判
+	for i := 0; i < n; i++ {
+		assert.True(t, c.MayContainString(strconv.Itoa(i)))
+		assert.True(t, c.MayContain([]byte(strconv.Itoa(i))))
+	}
+	falsePositives := 0
+	for i := n; i < 2*n; i++ {
+		if c.MayContainString(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/n, 0.01)
+
+	// 删除一半的元素
+	for i := 0; i < n; i += 2 {
+		assert.True(t, c.DeleteString(strconv.Itoa(i)))
+	}
+	assert.Equal(t, uint64(n/2), c.Count())
+	for i := 1; i < n; i += 2 {
+		assert.True(t, c.MayContainString(strconv.Itoa(i)))
+	}
+	assert.False(t, c.Delete([]byte("not_exists")))
+}
+
+func TestCuckooFilter_Full(t *testing.T) {
+	c := NewCuckooFilter(8)
+	added := 0
+	for i := 0; i < 100; i++ {
+		if !c.AddString(strconv.Itoa(i)) {
+			break
+		}
+		added++
+	}
+	assert.Less(t, added, 100)
+	assert.True(t, c.victimExists)
+	assert.LessOrEqual(t, c.FillRatio(), 1.0+1.0/float64(len(c.buckets)*bucketSize))
+	// 已经添加的元素依然可以查到, 包括被踢出的 victim
+	for i := 0; i < added; i++ {
+		assert.True(t, c.MayContainString(strconv.Itoa(i)))
+	}
+	// 删除之后可以继续添加
+	for i := 0; i < added; i++ {
+		assert.True(t, c.DeleteString(strconv.Itoa(i)))
+	}
+	assert.False(t, c.victimExists)
+	assert.Equal(t, uint64(0), c.Count())
+	assert.True(t, c.AddString("foo"))
+}
+
+func TestCuckooFilter_MarshalBinary(t *testing.T) {
+	c := NewCuckooFilter(100)
+	for i := 0; i < 50; i++ {
+		c.AddString(strconv.Itoa(i))
+	}
+	data, err := c.MarshalBinary()
+	require.NoError(t, err)
+
+	got := &CuckooFilter{}
+	require.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, c.buckets, got.buckets)
+	assert.Equal(t, c.Count(), got.Count())
+	for i := 0; i < 50; i++ {
+		assert.True(t, got.MayContainString(strconv.Itoa(i)))
+	}
+
+	assert.Equal(t, errInvalidFilterData, got.UnmarshalBinary(data[:10]))
+	assert.Equal(t, errInvalidFilterData, got.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestCuckooFilter_UnmarshalBinaryInvalid(t *testing.T) {
+	c := NewCuckooFilter(10)
+	c.AddString("foo")
+	valid, err := c.MarshalBinary()
+	require.NoError(t, err)
+	modify := func(fn func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		fn(data)
+		return data
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too_short", data: valid[:10]},
+		{name: "truncated", data: valid[:len(valid)-1]},
+		{name: "zero_buckets", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data, 0) })},
+		{name: "not_power_of_two", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data, 3) })},
+		{name: "num_overflow", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data, 1<<61) })[:27]},
+		{name: "count_mismatch", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data[8:], 2) })},
+		{name: "count_too_large", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data[8:], 1<<63) })},
+		{name: "invalid_victim_flag", data: modify(func(data []byte) { data[16] = 2 })},
+		{name: "empty_victim", data: modify(func(data []byte) {
+			data[16] = 1
+			binary.BigEndian.PutUint64(data[8:], 2)
+		})},
+		{name: "victim_index_out_of_range", data: modify(func(data []byte) { binary.BigEndian.PutUint64(data[19:], 1<<20) })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, errInvalidFilterData, (&CuckooFilter{}).UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+// TestCuckooFilter_IndexSpread 只有高位不同的元素也应该分散到不同的桶中.
+func TestCuckooFilter_IndexSpread(t *testing.T) {
+	c := NewCuckooFilter(60)
+	require.Equal(t, 16, len(c.buckets))
+	buckets := make(map[uint64]struct{})
+	for i := 0; i < 16; i++ {
+		h, _ := hash128([]byte{byte(i << 4)})
+		_, b := c.index(h)
+		buckets[b] = struct{}{}
+	}
+	assert.GreaterOrEqual(t, len(buckets), 8)
+}
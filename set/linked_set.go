@@ -0,0 +1,83 @@
+package set
+
+import "github.com/udugong/ukit/iterx"
+
+// linkedSetNode 双向链表节点.
+type linkedSetNode[T comparable] struct {
+	key        T
+	prev, next *linkedSetNode[T]
+}
+
+// LinkedSet 保持插入顺序的集合.
+// 使用 map 索引双向链表的节点, Add, Delete 和 Exists 的时间复杂度都是 O(1).
+type LinkedSet[T comparable] struct {
+	nodes map[T]*linkedSetNode[T]
+	head  *linkedSetNode[T] // 哨兵节点, head.next 为第一个元素, head.prev 为最后一个元素
+}
+
+// NewLinkedSet 创建一个保持插入顺序的集合.
+func NewLinkedSet[T comparable](cap int) *LinkedSet[T] {
+	head := &linkedSetNode[T]{}
+	head.prev, head.next = head, head
+	return &LinkedSet[T]{
+		nodes: make(map[T]*linkedSetNode[T], cap),
+		head:  head,
+	}
+}
+
+// Add 添加元素到集合末尾.
+// 如果元素已经存在则保持原来的位置.
+func (s *LinkedSet[T]) Add(key T) {
+	if _, ok := s.nodes[key]; ok {
+		return
+	}
+	node := &linkedSetNode[T]{key: key, prev: s.head.prev, next: s.head}
+	s.head.prev.next = node
+	s.head.prev = node
+	s.nodes[key] = node
+}
+
+func (s *LinkedSet[T]) Delete(key T) {
+	node, ok := s.nodes[key]
+	if !ok {
+		return
+	}
+	node.prev.next = node.next
+	node.next.prev = node.prev
+	node.prev, node.next = nil, nil
+	delete(s.nodes, key)
+}
+
+func (s *LinkedSet[T]) Exists(key T) bool {
+	_, ok := s.nodes[key]
+	return ok
+}
+
+// Keys 按照插入顺序返回所有元素.
+func (s *LinkedSet[T]) Keys() []T {
+	ans := make([]T, 0, len(s.nodes))
+	for node := s.head.next; node != s.head; node = node.next {
+		ans = append(ans, node.key)
+	}
+	return ans
+}
+
+// Len 返回集合中元素的个数.
+func (s *LinkedSet[T]) Len() int {
+	return len(s.nodes)
+}
+
+// Each 按照插入顺序遍历元素, fn 返回 false 时停止遍历.
+// 遍历过程中不能修改集合.
+func (s *LinkedSet[T]) Each(fn func(key T) bool) {
+	for node := s.head.next; node != s.head; node = node.next {
+		if !fn(node.key) {
+			return
+		}
+	}
+}
+
+// All 按照插入顺序迭代元素. 迭代过程中不能修改集合.
+func (s *LinkedSet[T]) All() iterx.Seq[T] {
+	return s.Each
+}
@@ -0,0 +1,74 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedSet_Keys(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s *LinkedSet[int])
+		want []int
+	}{
+		{
+			name: "insertion_order",
+			op: func(s *LinkedSet[int]) {
+				for _, key := range []int{3, 1, 2} {
+					s.Add(key)
+				}
+			},
+			want: []int{3, 1, 2},
+		},
+		{
+			name: "add_existing_keeps_position",
+			op: func(s *LinkedSet[int]) {
+				for _, key := range []int{3, 1, 2, 3} {
+					s.Add(key)
+				}
+			},
+			want: []int{3, 1, 2},
+		},
+		{
+			name: "delete_then_add_moves_to_end",
+			op: func(s *LinkedSet[int]) {
+				for _, key := range []int{3, 1, 2} {
+					s.Add(key)
+				}
+				s.Delete(3)
+				s.Add(3)
+			},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "delete_all",
+			op: func(s *LinkedSet[int]) {
+				s.Add(1)
+				s.Delete(1)
+			},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewLinkedSet[int](0)
+			tt.op(s)
+			assert.Equal(t, tt.want, s.Keys())
+			assert.Equal(t, len(tt.want), s.Len())
+		})
+	}
+}
+
+func TestLinkedSet_Each(t *testing.T) {
+	s := NewLinkedSet[int](0)
+	for _, key := range []int{3, 1, 2} {
+		s.Add(key)
+	}
+	var got []int
+	s.Each(func(key int) bool {
+		got = append(got, key)
+		return key != 1
+	})
+	assert.Equal(t, []int{3, 1}, got)
+}
@@ -1,5 +1,7 @@
 package set
 
+import "github.com/udugong/ukit/iterx"
+
 type MapSet[T comparable] map[T]struct{}
 
 func New[T comparable](cap int) MapSet[T] {
@@ -26,3 +28,70 @@ func (s MapSet[T]) Keys() []T {
 	}
 	return ans
 }
+
+// Len 返回集合中元素的个数.
+func (s MapSet[T]) Len() int {
+	return len(s)
+}
+
+// Clone 返回集合的浅拷贝.
+func (s MapSet[T]) Clone() MapSet[T] {
+	res := make(MapSet[T], len(s))
+	for key := range s {
+		res[key] = struct{}{}
+	}
+	return res
+}
+
+// Clear 删除集合中所有的元素.
+func (s MapSet[T]) Clear() {
+	for key := range s {
+		delete(s, key)
+	}
+}
+
+// Union 返回 s 和 other 的并集.
+func (s MapSet[T]) Union(other Set[T]) MapSet[T] {
+	return Union[T](s, other)
+}
+
+// Intersection 返回 s 和 other 的交集.
+func (s MapSet[T]) Intersection(other Set[T]) MapSet[T] {
+	return Intersection[T](s, other)
+}
+
+// Difference 返回在 s 中但不在 other 中的元素.
+func (s MapSet[T]) Difference(other Set[T]) MapSet[T] {
+	return Difference[T](s, other)
+}
+
+// SymmetricDifference 返回只在 s 或 other 其中一个集合中的元素.
+func (s MapSet[T]) SymmetricDifference(other Set[T]) MapSet[T] {
+	return SymmetricDifference[T](s, other)
+}
+
+// IsSubset 返回 s 是否是 other 的子集.
+func (s MapSet[T]) IsSubset(other Set[T]) bool {
+	return IsSubset[T](s, other)
+}
+
+// IsSuperset 返回 s 是否是 other 的超集.
+func (s MapSet[T]) IsSuperset(other Set[T]) bool {
+	return IsSuperset[T](s, other)
+}
+
+// Equal 返回 s 和 other 是否包含相同的元素.
+func (s MapSet[T]) Equal(other Set[T]) bool {
+	return Equal[T](s, other)
+}
+
+// All 迭代集合中的元素, 顺序是不确定的.
+func (s MapSet[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		for key := range s {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
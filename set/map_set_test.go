@@ -99,6 +99,39 @@ func TestMapSet_Keys(t *testing.T) {
 	}
 }
 
+func TestMapSet_Len(t *testing.T) {
+	assert.Equal(t, 0, MapSet[int]{}.Len())
+	assert.Equal(t, 2, MapSet[int]{1: {}, 2: {}}.Len())
+}
+
+func TestMapSet_Clone(t *testing.T) {
+	s := MapSet[int]{1: {}, 2: {}}
+	c := s.Clone()
+	assert.Equal(t, s, c)
+	c.Add(3)
+	assert.False(t, s.Exists(3))
+}
+
+func TestMapSet_Clear(t *testing.T) {
+	s := MapSet[int]{1: {}, 2: {}}
+	s.Clear()
+	assert.Equal(t, MapSet[int]{}, s)
+}
+
+func TestMapSet_Algebra(t *testing.T) {
+	// 具体的运算逻辑在 algebra_test.go 中测试
+	a := MapSet[int]{1: {}, 2: {}, 3: {}}
+	b := MapSet[int]{2: {}, 3: {}, 4: {}}
+	assert.Equal(t, MapSet[int]{1: {}, 2: {}, 3: {}, 4: {}}, a.Union(b))
+	assert.Equal(t, MapSet[int]{2: {}, 3: {}}, a.Intersection(b))
+	assert.Equal(t, MapSet[int]{1: {}}, a.Difference(b))
+	assert.Equal(t, MapSet[int]{1: {}, 4: {}}, a.SymmetricDifference(b))
+	assert.True(t, MapSet[int]{2: {}}.IsSubset(a))
+	assert.True(t, a.IsSuperset(MapSet[int]{2: {}}))
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.Equal(a.Clone()))
+}
+
 func equal(nums []int, m map[int]struct{}) bool {
 	for _, num := range nums {
 		_, ok := m[num]
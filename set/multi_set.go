@@ -0,0 +1,184 @@
+package set
+
+import (
+	"github.com/udugong/ukit/heap"
+	"github.com/udugong/ukit/iterx"
+)
+
+// Element 多重集合中的元素及其出现次数.
+type Element[T comparable] struct {
+	Key   T
+	Count int
+}
+
+// MultiSet 多重集合, 记录每个元素出现的次数.
+// 可以用来统计标签, 错误码和单词等出现的次数.
+type MultiSet[T comparable] struct {
+	counts map[T]int
+	total  int
+}
+
+// NewMultiSet 创建一个多重集合.
+func NewMultiSet[T comparable](cap int) *MultiSet[T] {
+	return &MultiSet[T]{
+		counts: make(map[T]int, cap),
+	}
+}
+
+// Add 将 key 的出现次数增加 n.
+// n 小于等于0时不做任何操作.
+func (m *MultiSet[T]) Add(key T, n int) {
+	if n <= 0 {
+		return
+	}
+	m.counts[key] += n
+	m.total += n
+}
+
+// Remove 将 key 的出现次数减少 n, 最多减少到0.
+// 出现次数为0的元素会被删除, n 小于等于0时不做任何操作.
+func (m *MultiSet[T]) Remove(key T, n int) {
+	if n <= 0 {
+		return
+	}
+	count, ok := m.counts[key]
+	if !ok {
+		return
+	}
+	if n >= count {
+		delete(m.counts, key)
+		m.total -= count
+		return
+	}
+	m.counts[key] = count - n
+	m.total -= n
+}
+
+// Count 返回 key 出现的次数.
+func (m *MultiSet[T]) Count(key T) int {
+	return m.counts[key]
+}
+
+// Total 返回所有元素出现次数的总和.
+func (m *MultiSet[T]) Total() int {
+	return m.total
+}
+
+// Len 返回不同元素的个数.
+func (m *MultiSet[T]) Len() int {
+	return len(m.counts)
+}
+
+// Distinct 返回所有不同的元素.
+func (m *MultiSet[T]) Distinct() []T {
+	ans := make([]T, 0, len(m.counts))
+	for key := range m.counts {
+		ans = append(ans, key)
+	}
+	return ans
+}
+
+// MostCommon 按照出现次数从多到少返回前 n 个元素.
+// n 小于0或者大于不同元素的个数时返回所有元素.
+// 出现次数相同的元素之间的顺序是不确定的.
+func (m *MultiSet[T]) MostCommon(n int) []Element[T] {
+	if n < 0 || n > len(m.counts) {
+		n = len(m.counts)
+	}
+	if n == 0 {
+		return []Element[T]{}
+	}
+	// 使用大小为 n 的小顶堆保存出现次数最多的元素, 时间复杂度为 O(m log n)
+	h := make(elementHeap[T], 0, n)
+	for key, count := range m.counts {
+		e := Element[T]{Key: key, Count: count}
+		if h.Len() < n {
+			heap.Push[Element[T]](&h, e)
+			continue
+		}
+		if count > h[0].Count {
+			h[0] = e
+			heap.Fix[Element[T]](&h, 0)
+		}
+	}
+	ans := make([]Element[T], h.Len())
+	for i := len(ans) - 1; i >= 0; i-- {
+		ans[i] = heap.Pop[Element[T]](&h)
+	}
+	return ans
+}
+
+// Union 返回 m 和 other 的并集, 每个元素的出现次数取两者中的较大值.
+func (m *MultiSet[T]) Union(other *MultiSet[T]) *MultiSet[T] {
+	res := m.Clone()
+	for key, count := range other.counts {
+		if c := res.counts[key]; count > c {
+			res.Add(key, count-c)
+		}
+	}
+	return res
+}
+
+// Intersection 返回 m 和 other 的交集, 每个元素的出现次数取两者中的较小值.
+func (m *MultiSet[T]) Intersection(other *MultiSet[T]) *MultiSet[T] {
+	a, b := m, other
+	if len(a.counts) > len(b.counts) {
+		a, b = b, a
+	}
+	res := NewMultiSet[T](len(a.counts))
+	for key, count := range a.counts {
+		if c := b.counts[key]; c < count {
+			count = c
+		}
+		res.Add(key, count)
+	}
+	return res
+}
+
+// Sum 返回 m 和 other 的和, 每个元素的出现次数为两者之和.
+func (m *MultiSet[T]) Sum(other *MultiSet[T]) *MultiSet[T] {
+	res := m.Clone()
+	for key, count := range other.counts {
+		res.Add(key, count)
+	}
+	return res
+}
+
+// Clone 返回多重集合的拷贝.
+func (m *MultiSet[T]) Clone() *MultiSet[T] {
+	res := NewMultiSet[T](len(m.counts))
+	for key, count := range m.counts {
+		res.counts[key] = count
+	}
+	res.total = m.total
+	return res
+}
+
+// elementHeap 按照出现次数排列的小顶堆, 实现 heap.Interface.
+type elementHeap[T comparable] []Element[T]
+
+func (h *elementHeap[T]) Len() int           { return len(*h) }
+func (h *elementHeap[T]) Less(i, j int) bool { return (*h)[i].Count < (*h)[j].Count }
+func (h *elementHeap[T]) Swap(i, j int)      { (*h)[i], (*h)[j] = (*h)[j], (*h)[i] }
+
+// Push add x as element Len().
+func (h *elementHeap[T]) Push(x Element[T]) {
+	*h = append(*h, x)
+}
+
+// Pop remove and return element Len() - 1.
+func (h *elementHeap[T]) Pop() (x Element[T]) {
+	*h, x = (*h)[:h.Len()-1], (*h)[h.Len()-1]
+	return
+}
+
+// All 迭代每个元素及其出现次数, 顺序是不确定的. 迭代过程中不能修改集合.
+func (m *MultiSet[T]) All() iterx.Seq[Element[T]] {
+	return func(yield func(Element[T]) bool) {
+		for key, count := range m.counts {
+			if !yield(Element[T]{Key: key, Count: count}) {
+				return
+			}
+		}
+	}
+}
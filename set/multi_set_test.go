@@ -0,0 +1,157 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMultiSet(keys ...string) *MultiSet[string] {
+	m := NewMultiSet[string](len(keys))
+	for _, key := range keys {
+		m.Add(key, 1)
+	}
+	return m
+}
+
+func TestMultiSet_AddRemove(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        func(m *MultiSet[string])
+		wantCount map[string]int
+		wantTotal int
+	}{
+		{
+			name: "add",
+			op: func(m *MultiSet[string]) {
+				m.Add("a", 2)
+				m.Add("a", 1)
+				m.Add("b", 1)
+			},
+			wantCount: map[string]int{"a": 3, "b": 1},
+			wantTotal: 4,
+		},
+		{
+			name: "add_non_positive",
+			op: func(m *MultiSet[string]) {
+				m.Add("a", 0)
+				m.Add("a", -1)
+			},
+			wantCount: map[string]int{},
+			wantTotal: 0,
+		},
+		{
+			name: "remove",
+			op: func(m *MultiSet[string]) {
+				m.Add("a", 3)
+				m.Remove("a", 2)
+			},
+			wantCount: map[string]int{"a": 1},
+			wantTotal: 1,
+		},
+		{
+			name: "remove_more_than_count",
+			op: func(m *MultiSet[string]) {
+				m.Add("a", 3)
+				m.Add("b", 1)
+				m.Remove("a", 10)
+				m.Remove("c", 1)
+				m.Remove("b", -1)
+			},
+			wantCount: map[string]int{"b": 1},
+			wantTotal: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMultiSet[string](0)
+			tt.op(m)
+			assert.Equal(t, tt.wantCount, m.counts)
+			assert.Equal(t, tt.wantTotal, m.Total())
+			assert.Equal(t, len(tt.wantCount), m.Len())
+			for key, count := range tt.wantCount {
+				assert.Equal(t, count, m.Count(key))
+			}
+			assert.Equal(t, 0, m.Count("not_exists"))
+		})
+	}
+}
+
+func TestMultiSet_Distinct(t *testing.T) {
+	m := newMultiSet("a", "b", "a", "c")
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, m.Distinct())
+}
+
+func TestMultiSet_MostCommon(t *testing.T) {
+	m := newMultiSet("a", "b", "b", "c", "c", "c", "d", "d", "d", "d")
+	tests := []struct {
+		name string
+		n    int
+		want []Element[string]
+	}{
+		{
+			name: "top_2",
+			n:    2,
+			want: []Element[string]{{Key: "d", Count: 4}, {Key: "c", Count: 3}},
+		},
+		{
+			name: "all",
+			n:    -1,
+			want: []Element[string]{{"d", 4}, {"c", 3}, {"b", 2}, {"a", 1}},
+		},
+		{
+			name: "more_than_len",
+			n:    10,
+			want: []Element[string]{{"d", 4}, {"c", 3}, {"b", 2}, {"a", 1}},
+		},
+		{
+			name: "zero",
+			n:    0,
+			want: []Element[string]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.MostCommon(tt.n))
+		})
+	}
+}
+
+func TestMultiSet_Operations(t *testing.T) {
+	a := newMultiSet("x", "x", "x", "y")
+	b := newMultiSet("x", "y", "y", "z")
+	tests := []struct {
+		name      string
+		got       *MultiSet[string]
+		wantCount map[string]int
+		wantTotal int
+	}{
+		{
+			name:      "union",
+			got:       a.Union(b),
+			wantCount: map[string]int{"x": 3, "y": 2, "z": 1},
+			wantTotal: 6,
+		},
+		{
+			name:      "intersection",
+			got:       a.Intersection(b),
+			wantCount: map[string]int{"x": 1, "y": 1},
+			wantTotal: 2,
+		},
+		{
+			name:      "sum",
+			got:       a.Sum(b),
+			wantCount: map[string]int{"x": 4, "y": 3, "z": 1},
+			wantTotal: 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCount, tt.got.counts)
+			assert.Equal(t, tt.wantTotal, tt.got.Total())
+		})
+	}
+	// 运算不会修改参数
+	assert.Equal(t, map[string]int{"x": 3, "y": 1}, a.counts)
+	assert.Equal(t, map[string]int{"x": 1, "y": 2, "z": 1}, b.counts)
+}
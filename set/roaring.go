@@ -0,0 +1,521 @@
+package set
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+const (
+	// serialCookieNoRunContainer 不包含 run 容器时的序列化标识.
+	serialCookieNoRunContainer = 12346
+	// serialCookie 包含 run 容器时的序列化标识.
+	serialCookie = 12347
+	// noOffsetThreshold 包含 run 容器且容器数量小于该值时不写入偏移量.
+	noOffsetThreshold = 4
+)
+
+var errInvalidRoaringData = errors.New("ukit: 非法的 Roaring 位图数据")
+
+// RoaringBitmap 压缩位图, 适用于大规模的稀疏 uint32 集合.
+// 元素按照高16位分桶, 每个桶根据元素的分布使用数组, 位图或者 run 容器存储低16位.
+// 序列化格式与 Roaring 官方规范兼容, 可以与 Java, C 和 Python 等实现互相读写.
+// 参考 https://github.com/RoaringBitmap/RoaringFormatSpec
+type RoaringBitmap struct {
+	keys       []uint16 // 高16位, 升序
+	containers []*container
+}
+
+// NewRoaringBitmap 创建一个 Roaring 位图.
+func NewRoaringBitmap(vals ...uint32) *RoaringBitmap {
+	r := &RoaringBitmap{}
+	for _, v := range vals {
+		r.Add(v)
+	}
+	return r
+}
+
+// index 返回 key 所在的下标, 如果不存在则返回应该插入的位置和 false.
+func (r *RoaringBitmap) index(key uint16) (int, bool) {
+	i := searchUint16(r.keys, key)
+	return i, i < len(r.keys) && r.keys[i] == key
+}
+
+// Add 添加元素.
+func (r *RoaringBitmap) Add(x uint32) {
+	hb, lb := uint16(x>>16), uint16(x)
+	i, ok := r.index(hb)
+	if !ok {
+		r.keys = append(r.keys, 0)
+		copy(r.keys[i+1:], r.keys[i:])
+		r.keys[i] = hb
+		r.containers = append(r.containers, nil)
+		copy(r.containers[i+1:], r.containers[i:])
+		r.containers[i] = newArrayContainer(nil)
+	}
+	r.containers[i].add(lb)
+}
+
+// Remove 删除元素.
+func (r *RoaringBitmap) Remove(x uint32) {
+	i, ok := r.index(uint16(x >> 16))
+	if !ok {
+		return
+	}
+	c := r.containers[i]
+	if c.remove(uint16(x)) && c.card == 0 {
+		r.removeAt(i)
+	}
+}
+
+func (r *RoaringBitmap) removeAt(i int) {
+	r.keys = append(r.keys[:i], r.keys[i+1:]...)
+	copy(r.containers[i:], r.containers[i+1:])
+	r.containers[len(r.containers)-1] = nil
+	r.containers = r.containers[:len(r.containers)-1]
+}
+
+// Contains 返回元素是否存在.
+func (r *RoaringBitmap) Contains(x uint32) bool {
+	i, ok := r.index(uint16(x >> 16))
+	return ok && r.containers[i].contains(uint16(x))
+}
+
+// Cardinality 返回元素个数.
+func (r *RoaringBitmap) Cardinality() uint64 {
+	var n uint64
+	for _, c := range r.containers {
+		n += uint64(c.card)
+	}
+	return n
+}
+
+// IsEmpty 返回位图是否为空.
+func (r *RoaringBitmap) IsEmpty() bool {
+	return len(r.containers) == 0
+}
+
+// Rank 返回小于等于 x 的元素个数.
+func (r *RoaringBitmap) Rank(x uint32) uint64 {
+	hb := uint16(x >> 16)
+	var n uint64
+	for i, key := range r.keys {
+		if key > hb {
+			break
+		}
+		if key < hb {
+			n += uint64(r.containers[i].card)
+			continue
+		}
+		n += uint64(r.containers[i].rank(uint16(x)))
+	}
+	return n
+}
+
+// Select 返回第 i 小的元素, i 从0开始.
+// 如果 i 大于等于元素个数则返回 false.
+func (r *RoaringBitmap) Select(i uint64) (uint32, bool) {
+	for k, c := range r.containers {
+		if i >= uint64(c.card) {
+			i -= uint64(c.card)
+			continue
+		}
+		return uint32(r.keys[k])<<16 | uint32(c.selectAt(int(i))), true
+	}
+	return 0, false
+}
+
+// Min 返回最小的元素.
+// 如果位图为空则返回 false.
+func (r *RoaringBitmap) Min() (uint32, bool) {
+	return r.Select(0)
+}
+
+// Max 返回最大的元素.
+// 如果位图为空则返回 false.
+func (r *RoaringBitmap) Max() (uint32, bool) {
+	n := len(r.containers)
+	if n == 0 {
+		return 0, false
+	}
+	c := r.containers[n-1]
+	return uint32(r.keys[n-1])<<16 | uint32(c.selectAt(c.card-1)), true
+}
+
+// Each 按照升序遍历元素, fn 返回 false 时停止遍历.
+// 遍历过程中不能修改位图.
+func (r *RoaringBitmap) Each(fn func(x uint32) bool) {
+	for i, c := range r.containers {
+		hb := uint32(r.keys[i]) << 16
+		if !c.each(func(x uint16) bool {
+			return fn(hb | uint32(x))
+		}) {
+			return
+		}
+	}
+}
+
+// ToArray 按照升序返回所有元素.
+func (r *RoaringBitmap) ToArray() []uint32 {
+	ans := make([]uint32, 0, r.Cardinality())
+	r.Each(func(x uint32) bool {
+		ans = append(ans, x)
+		return true
+	})
+	return ans
+}
+
+// Clone 返回位图的深拷贝.
+func (r *RoaringBitmap) Clone() *RoaringBitmap {
+	res := &RoaringBitmap{
+		keys:       append([]uint16(nil), r.keys...),
+		containers: make([]*container, len(r.containers)),
+	}
+	for i, c := range r.containers {
+		res.containers[i] = c.clone()
+	}
+	return res
+}
+
+// Equal 返回 r 和 other 是否包含相同的元素.
+func (r *RoaringBitmap) Equal(other *RoaringBitmap) bool {
+	if len(r.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range r.keys {
+		if key != other.keys[i] || r.containers[i].card != other.containers[i].card {
+			return false
+		}
+		if r.containers[i].xor(other.containers[i]).card != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// And 返回 r 和 other 的交集.
+func (r *RoaringBitmap) And(other *RoaringBitmap) *RoaringBitmap {
+	res := &RoaringBitmap{}
+	i, j := 0, 0
+	for i < len(r.keys) && j < len(other.keys) {
+		switch a, b := r.keys[i], other.keys[j]; {
+		case a < b:
+			i++
+		case a > b:
+			j++
+		default:
+			res.appendContainer(a, r.containers[i].and(other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return res
+}
+
+// Or 返回 r 和 other 的并集.
+func (r *RoaringBitmap) Or(other *RoaringBitmap) *RoaringBitmap {
+	return r.merge(other, (*container).or, true)
+}
+
+// Xor 返回 r 和 other 的对称差集.
+func (r *RoaringBitmap) Xor(other *RoaringBitmap) *RoaringBitmap {
+	return r.merge(other, (*container).xor, true)
+}
+
+// AndNot 返回在 r 中但不在 other 中的元素.
+func (r *RoaringBitmap) AndNot(other *RoaringBitmap) *RoaringBitmap {
+	return r.merge(other, (*container).andNot, false)
+}
+
+// merge 合并两个位图中的容器.
+// 只在 r 中存在的容器直接拷贝, keepOther 为 true 时只在 other 中存在的容器也直接拷贝.
+func (r *RoaringBitmap) merge(other *RoaringBitmap,
+	op func(a, b *container) *container, keepOther bool) *RoaringBitmap {
+	res := &RoaringBitmap{}
+	i, j := 0, 0
+	for i < len(r.keys) && j < len(other.keys) {
+		switch a, b := r.keys[i], other.keys[j]; {
+		case a < b:
+			res.appendContainer(a, r.containers[i].clone())
+			i++
+		case a > b:
+			if keepOther {
+				res.appendContainer(b, other.containers[j].clone())
+			}
+			j++
+		default:
+			res.appendContainer(a, op(r.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	for ; i < len(r.keys); i++ {
+		res.appendContainer(r.keys[i], r.containers[i].clone())
+	}
+	for ; keepOther && j < len(other.keys); j++ {
+		res.appendContainer(other.keys[j], other.containers[j].clone())
+	}
+	return res
+}
+
+// appendContainer 在末尾添加非空的容器.
+func (r *RoaringBitmap) appendContainer(key uint16, c *container) {
+	if c.card == 0 {
+		return
+	}
+	r.keys = append(r.keys, key)
+	r.containers = append(r.containers, c)
+}
+
+// RunOptimize 将适合的容器转换为 run 容器以减少内存占用和序列化后的大小.
+// 对连续的整数区间效果最好.
+func (r *RoaringBitmap) RunOptimize() {
+	for _, c := range r.containers {
+		c.runOptimize()
+	}
+}
+
+// AsSet 返回一个以 r 为底层存储的 Set[uint32].
+// 对返回值的修改会直接反映到 r 上.
+func (r *RoaringBitmap) AsSet() Set[uint32] {
+	return roaringAdapter{r: r}
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler.
+// 使用 Roaring 官方规范的可移植格式, 所有整数均为小端序.
+func (r *RoaringBitmap) MarshalBinary() ([]byte, error) {
+	n := len(r.containers)
+	hasRun := false
+	for _, c := range r.containers {
+		if c.typ == runContainer {
+			hasRun = true
+			break
+		}
+	}
+
+	// 计算头部长度
+	headerSize := 8
+	if hasRun {
+		headerSize = 4 + (n+7)/8
+	}
+	headerSize += 4 * n // key 和 cardinality-1
+	writeOffsets := !hasRun || n >= noOffsetThreshold
+	if writeOffsets {
+		headerSize += 4 * n
+	}
+	size := headerSize
+	for _, c := range r.containers {
+		size += c.serializedSize()
+	}
+
+	data := make([]byte, size)
+	pos := 0
+	if hasRun {
+		binary.LittleEndian.PutUint32(data, serialCookie|uint32(n-1)<<16)
+		pos = 4
+		for i, c := range r.containers {
+			if c.typ == runContainer {
+				data[pos+i/8] |= 1 << (i % 8)
+			}
+		}
+		pos += (n + 7) / 8
+	} else {
+		binary.LittleEndian.PutUint32(data, serialCookieNoRunContainer)
+		binary.LittleEndian.PutUint32(data[4:], uint32(n))
+		pos = 8
+	}
+	for i, c := range r.containers {
+		binary.LittleEndian.PutUint16(data[pos:], r.keys[i])
+		binary.LittleEndian.PutUint16(data[pos+2:], uint16(c.card-1))
+		pos += 4
+	}
+	offset := headerSize
+	if writeOffsets {
+		for _, c := range r.containers {
+			binary.LittleEndian.PutUint32(data[pos:], uint32(offset))
+			pos += 4
+			offset += c.serializedSize()
+		}
+	}
+	for _, c := range r.containers {
+		switch c.typ {
+		case arrayContainer:
+			for _, v := range c.array {
+				binary.LittleEndian.PutUint16(data[pos:], v)
+				pos += 2
+			}
+		case bitmapContainer:
+			for _, word := range c.bitmap {
+				binary.LittleEndian.PutUint64(data[pos:], word)
+				pos += 8
+			}
+		default:
+			binary.LittleEndian.PutUint16(data[pos:], uint16(len(c.runs)))
+			pos += 2
+			for _, iv := range c.runs {
+				binary.LittleEndian.PutUint16(data[pos:], iv.start)
+				binary.LittleEndian.PutUint16(data[pos+2:], iv.last-iv.start)
+				pos += 4
+			}
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler.
+// 支持 Roaring 官方规范的可移植格式.
+func (r *RoaringBitmap) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errInvalidRoaringData
+	}
+	cookie := binary.LittleEndian.Uint32(data)
+	var (
+		n      int
+		pos    int
+		runBit []byte
+	)
+	switch {
+	case cookie == serialCookieNoRunContainer:
+		if len(data) < 8 {
+			return errInvalidRoaringData
+		}
+		n = int(binary.LittleEndian.Uint32(data[4:]))
+		pos = 8
+	case cookie&0xFFFF == serialCookie:
+		n = int(cookie>>16) + 1
+		pos = 4 + (n+7)/8
+		if len(data) < pos {
+			return errInvalidRoaringData
+		}
+		runBit = data[4:pos]
+	default:
+		return errInvalidRoaringData
+	}
+	// 每个容器至少需要4字节的描述信息
+	if n > (len(data)-pos)/4 {
+		return errInvalidRoaringData
+	}
+
+	keys := make([]uint16, n)
+	cards := make([]int, n)
+	for i := 0; i < n; i++ {
+		keys[i] = binary.LittleEndian.Uint16(data[pos:])
+		cards[i] = int(binary.LittleEndian.Uint16(data[pos+2:])) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return errInvalidRoaringData
+		}
+		pos += 4
+	}
+	if runBit == nil || n >= noOffsetThreshold {
+		// 容器是连续存储的, 不需要使用偏移量
+		pos += 4 * n
+	}
+
+	containers := make([]*container, n)
+	for i := 0; i < n; i++ {
+		isRun := runBit != nil && runBit[i/8]&(1<<(i%8)) != 0
+		if pos > len(data) {
+			return errInvalidRoaringData
+		}
+		c, size, err := readContainer(data[pos:], cards[i], isRun)
+		if err != nil {
+			return err
+		}
+		containers[i] = c
+		pos += size
+	}
+	r.keys = keys
+	r.containers = containers
+	return nil
+}
+
+// readContainer 读取一个容器, 返回容器和读取的字节数.
+func readContainer(data []byte, card int, isRun bool) (*container, int, error) {
+	switch {
+	case isRun:
+		if len(data) < 2 {
+			return nil, 0, errInvalidRoaringData
+		}
+		nruns := int(binary.LittleEndian.Uint16(data))
+		size := 2 + 4*nruns
+		if len(data) < size {
+			return nil, 0, errInvalidRoaringData
+		}
+		runs := make([]interval16, nruns)
+		total := 0
+		for i := range runs {
+			start := binary.LittleEndian.Uint16(data[2+4*i:])
+			length := binary.LittleEndian.Uint16(data[4+4*i:])
+			if int(start)+int(length) > 0xFFFF ||
+				(i > 0 && start <= runs[i-1].last) {
+				return nil, 0, errInvalidRoaringData
+			}
+			runs[i] = interval16{start: start, last: start + length}
+			total += int(length) + 1
+		}
+		if total != card {
+			return nil, 0, errInvalidRoaringData
+		}
+		return &container{typ: runContainer, card: card, runs: runs}, size, nil
+	case card <= arrayMaxSize:
+		size := 2 * card
+		if len(data) < size {
+			return nil, 0, errInvalidRoaringData
+		}
+		array := make([]uint16, card)
+		for i := range array {
+			array[i] = binary.LittleEndian.Uint16(data[2*i:])
+			if i > 0 && array[i] <= array[i-1] {
+				return nil, 0, errInvalidRoaringData
+			}
+		}
+		return newArrayContainer(array), size, nil
+	default:
+		size := 8 * bitmapWords
+		if len(data) < size {
+			return nil, 0, errInvalidRoaringData
+		}
+		words := make([]uint64, bitmapWords)
+		for i := range words {
+			words[i] = binary.LittleEndian.Uint64(data[8*i:])
+		}
+		c := newContainerFromWords(words)
+		if c.card != card {
+			return nil, 0, errInvalidRoaringData
+		}
+		return c, size, nil
+	}
+}
+
+// roaringAdapter 将 RoaringBitmap 适配为 Set[uint32].
+type roaringAdapter struct {
+	r *RoaringBitmap
+}
+
+func (a roaringAdapter) Add(key uint32) {
+	a.r.Add(key)
+}
+
+func (a roaringAdapter) Delete(key uint32) {
+	a.r.Remove(key)
+}
+
+func (a roaringAdapter) Exists(key uint32) bool {
+	return a.r.Contains(key)
+}
+
+// Keys 按照升序返回所有元素.
+func (a roaringAdapter) Keys() []uint32 {
+	return a.r.ToArray()
+}
+
+// Len 返回集合中元素的个数.
+func (a roaringAdapter) Len() int {
+	return int(a.r.Cardinality())
+}
+
+// All 按照升序迭代元素. 迭代过程中不能修改位图.
+func (r *RoaringBitmap) All() iterx.Seq[uint32] {
+	return r.Each
+}
@@ -0,0 +1,450 @@
+package set
+
+import (
+	"math/bits"
+	"sort"
+)
+
+const (
+	// arrayMaxSize 数组容器最多存储的元素个数.
+	// 超过该值时数组容器占用的内存会超过位图容器, 所以需要转换为位图容器.
+	arrayMaxSize = 4096
+	// bitmapWords 位图容器中 uint64 的个数, 共 65536 位.
+	bitmapWords = 1024
+)
+
+type containerType uint8
+
+const (
+	arrayContainer containerType = iota
+	bitmapContainer
+	runContainer
+)
+
+// interval16 run 容器中的连续区间 [start, last].
+type interval16 struct {
+	start uint16
+	last  uint16
+}
+
+func (iv interval16) length() int {
+	return int(iv.last) - int(iv.start) + 1
+}
+
+// container Roaring 位图中存储低16位的容器.
+// 除了 run 容器以外, 元素个数小于等于 arrayMaxSize 时一定是数组容器,
+// 否则一定是位图容器, 序列化格式依赖于这个约定.
+type container struct {
+	typ    containerType
+	card   int          // 元素个数
+	array  []uint16     // 数组容器, 升序
+	bitmap []uint64     // 位图容器, 长度为 bitmapWords
+	runs   []interval16 // run 容器, 升序且互不相邻
+}
+
+func newArrayContainer(array []uint16) *container {
+	return &container{typ: arrayContainer, card: len(array), array: array}
+}
+
+// newContainerFromWords 根据元素个数选择数组容器或位图容器.
+// 可能会直接使用 words 作为位图容器的底层存储.
+func newContainerFromWords(words []uint64) *container {
+	card := 0
+	for _, word := range words {
+		card += bits.OnesCount64(word)
+	}
+	if card > arrayMaxSize {
+		return &container{typ: bitmapContainer, card: card, bitmap: words}
+	}
+	array := make([]uint16, 0, card)
+	for i, word := range words {
+		for word != 0 {
+			array = append(array, uint16(i*64+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+	return newArrayContainer(array)
+}
+
+func (c *container) clone() *container {
+	res := &container{typ: c.typ, card: c.card}
+	switch c.typ {
+	case arrayContainer:
+		res.array = append([]uint16(nil), c.array...)
+	case bitmapContainer:
+		res.bitmap = append([]uint64(nil), c.bitmap...)
+	default:
+		res.runs = append([]interval16(nil), c.runs...)
+	}
+	return res
+}
+
+// words 返回容器的位图表示.
+// 如果是位图容器则直接返回底层存储, 调用者不能修改返回值.
+func (c *container) words() []uint64 {
+	if c.typ == bitmapContainer {
+		return c.bitmap
+	}
+	words := make([]uint64, bitmapWords)
+	switch c.typ {
+	case arrayContainer:
+		for _, v := range c.array {
+			words[v/64] |= 1 << (v % 64)
+		}
+	default:
+		for _, iv := range c.runs {
+			setRange(words, int(iv.start), int(iv.last)+1)
+		}
+	}
+	return words
+}
+
+// setRange 将 words 中 [lo, hi) 范围内的位设置为1.
+func setRange(words []uint64, lo, hi int) {
+	for lo < hi {
+		w, b := lo/64, lo%64
+		n := 64 - b
+		if hi-lo < n {
+			n = hi - lo
+		}
+		if n == 64 {
+			words[w] = ^uint64(0)
+		} else {
+			words[w] |= ((1 << n) - 1) << b
+		}
+		lo += n
+	}
+}
+
+// materialize 将 run 容器转换为数组容器或位图容器.
+func (c *container) materialize() {
+	if c.typ != runContainer {
+		return
+	}
+	*c = *newContainerFromWords(c.words())
+}
+
+func (c *container) contains(x uint16) bool {
+	switch c.typ {
+	case arrayContainer:
+		i := searchUint16(c.array, x)
+		return i < len(c.array) && c.array[i] == x
+	case bitmapContainer:
+		return c.bitmap[x/64]&(1<<(x%64)) != 0
+	default:
+		i := sort.Search(len(c.runs), func(i int) bool {
+			return c.runs[i].last >= x
+		})
+		return i < len(c.runs) && c.runs[i].start <= x
+	}
+}
+
+// add 添加元素, 返回容器是否发生了变化.
+func (c *container) add(x uint16) bool {
+	if c.contains(x) {
+		return false
+	}
+	c.materialize()
+	if c.typ == arrayContainer && len(c.array) >= arrayMaxSize {
+		*c = container{typ: bitmapContainer, card: c.card, bitmap: c.words()}
+	}
+	switch c.typ {
+	case arrayContainer:
+		i := searchUint16(c.array, x)
+		c.array = append(c.array, 0)
+		copy(c.array[i+1:], c.array[i:])
+		c.array[i] = x
+	default:
+		c.bitmap[x/64] |= 1 << (x % 64)
+	}
+	c.card++
+	return true
+}
+
+// remove 删除元素, 返回容器是否发生了变化.
+func (c *container) remove(x uint16) bool {
+	if !c.contains(x) {
+		return false
+	}
+	c.materialize()
+	switch c.typ {
+	case arrayContainer:
+		i := searchUint16(c.array, x)
+		c.array = append(c.array[:i], c.array[i+1:]...)
+		c.card--
+	default:
+		c.bitmap[x/64] &^= 1 << (x % 64)
+		c.card--
+		if c.card <= arrayMaxSize {
+			*c = *newContainerFromWords(c.bitmap)
+		}
+	}
+	return true
+}
+
+// each 按照升序遍历元素, fn 返回 false 时停止遍历并返回 false.
+func (c *container) each(fn func(x uint16) bool) bool {
+	switch c.typ {
+	case arrayContainer:
+		for _, v := range c.array {
+			if !fn(v) {
+				return false
+			}
+		}
+	case bitmapContainer:
+		for i, word := range c.bitmap {
+			for word != 0 {
+				if !fn(uint16(i*64 + bits.TrailingZeros64(word))) {
+					return false
+				}
+				word &= word - 1
+			}
+		}
+	default:
+		for _, iv := range c.runs {
+			for v := int(iv.start); v <= int(iv.last); v++ {
+				if !fn(uint16(v)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// rank 返回小于等于 x 的元素个数.
+func (c *container) rank(x uint16) int {
+	switch c.typ {
+	case arrayContainer:
+		i := searchUint16(c.array, x)
+		if i < len(c.array) && c.array[i] == x {
+			i++
+		}
+		return i
+	case bitmapContainer:
+		n := 0
+		w := int(x / 64)
+		for _, word := range c.bitmap[:w] {
+			n += bits.OnesCount64(word)
+		}
+		mask := ^uint64(0) >> (63 - x%64)
+		return n + bits.OnesCount64(c.bitmap[w]&mask)
+	default:
+		n := 0
+		for _, iv := range c.runs {
+			if iv.start > x {
+				break
+			}
+			if iv.last <= x {
+				n += iv.length()
+			} else {
+				n += int(x-iv.start) + 1
+				break
+			}
+		}
+		return n
+	}
+}
+
+// selectAt 返回第 i 小的元素, i 从0开始且必须小于 c.card.
+func (c *container) selectAt(i int) uint16 {
+	switch c.typ {
+	case arrayContainer:
+		return c.array[i]
+	case bitmapContainer:
+		for w, word := range c.bitmap {
+			n := bits.OnesCount64(word)
+			if i >= n {
+				i -= n
+				continue
+			}
+			for ; i > 0; i-- {
+				word &= word - 1
+			}
+			return uint16(w*64 + bits.TrailingZeros64(word))
+		}
+	default:
+		for _, iv := range c.runs {
+			n := iv.length()
+			if i >= n {
+				i -= n
+				continue
+			}
+			return iv.start + uint16(i)
+		}
+	}
+	panic("ukit: 下标超出范围")
+}
+
+// numRuns 返回容器中连续区间的个数.
+func (c *container) numRuns() int {
+	if c.typ == runContainer {
+		return len(c.runs)
+	}
+	n := 0
+	last := -2
+	c.each(func(x uint16) bool {
+		if int(x) != last+1 {
+			n++
+		}
+		last = int(x)
+		return true
+	})
+	return n
+}
+
+// serializedSize 返回容器序列化后的字节数.
+func (c *container) serializedSize() int {
+	switch c.typ {
+	case arrayContainer:
+		return 2 * c.card
+	case bitmapContainer:
+		return 8 * bitmapWords
+	default:
+		return 2 + 4*len(c.runs)
+	}
+}
+
+// runOptimize 当 run 容器占用空间更小时转换为 run 容器, 否则转换为数组容器或位图容器.
+func (c *container) runOptimize() {
+	nruns := c.numRuns()
+	runSize := 2 + 4*nruns
+	size := 8 * bitmapWords
+	if c.card <= arrayMaxSize {
+		size = 2 * c.card
+	}
+	if runSize >= size {
+		c.materialize()
+		return
+	}
+	if c.typ == runContainer {
+		return
+	}
+	runs := make([]interval16, 0, nruns)
+	c.each(func(x uint16) bool {
+		if n := len(runs); n > 0 && int(runs[n-1].last)+1 == int(x) {
+			runs[n-1].last = x
+		} else {
+			runs = append(runs, interval16{start: x, last: x})
+		}
+		return true
+	})
+	*c = container{typ: runContainer, card: c.card, runs: runs}
+}
+
+// and 返回 c 和 other 的交集.
+func (c *container) and(other *container) *container {
+	if c.typ == arrayContainer || other.typ == arrayContainer {
+		a, b := c, other
+		if a.typ != arrayContainer {
+			a, b = b, a
+		}
+		res := make([]uint16, 0, len(a.array))
+		for _, v := range a.array {
+			if b.contains(v) {
+				res = append(res, v)
+			}
+		}
+		return newArrayContainer(res)
+	}
+	aw, bw := c.words(), other.words()
+	words := make([]uint64, bitmapWords)
+	for i := range words {
+		words[i] = aw[i] & bw[i]
+	}
+	return newContainerFromWords(words)
+}
+
+// or 返回 c 和 other 的并集.
+func (c *container) or(other *container) *container {
+	if c.typ == arrayContainer && other.typ == arrayContainer && c.card+other.card <= arrayMaxSize {
+		res := make([]uint16, 0, c.card+other.card)
+		i, j := 0, 0
+		for i < len(c.array) && j < len(other.array) {
+			switch a, b := c.array[i], other.array[j]; {
+			case a < b:
+				res = append(res, a)
+				i++
+			case a > b:
+				res = append(res, b)
+				j++
+			default:
+				res = append(res, a)
+				i++
+				j++
+			}
+		}
+		res = append(res, c.array[i:]...)
+		res = append(res, other.array[j:]...)
+		return newArrayContainer(res)
+	}
+	aw, bw := c.words(), other.words()
+	words := make([]uint64, bitmapWords)
+	for i := range words {
+		words[i] = aw[i] | bw[i]
+	}
+	return newContainerFromWords(words)
+}
+
+// xor 返回 c 和 other 的对称差集.
+func (c *container) xor(other *container) *container {
+	if c.typ == arrayContainer && other.typ == arrayContainer && c.card+other.card <= arrayMaxSize {
+		res := make([]uint16, 0, c.card+other.card)
+		i, j := 0, 0
+		for i < len(c.array) && j < len(other.array) {
+			switch a, b := c.array[i], other.array[j]; {
+			case a < b:
+				res = append(res, a)
+				i++
+			case a > b:
+				res = append(res, b)
+				j++
+			default:
+				i++
+				j++
+			}
+		}
+		res = append(res, c.array[i:]...)
+		res = append(res, other.array[j:]...)
+		return newArrayContainer(res)
+	}
+	aw, bw := c.words(), other.words()
+	words := make([]uint64, bitmapWords)
+	for i := range words {
+		words[i] = aw[i] ^ bw[i]
+	}
+	return newContainerFromWords(words)
+}
+
+// andNot 返回在 c 中但不在 other 中的元素.
+func (c *container) andNot(other *container) *container {
+	if c.typ == arrayContainer {
+		res := make([]uint16, 0, len(c.array))
+		for _, v := range c.array {
+			if !other.contains(v) {
+				res = append(res, v)
+			}
+		}
+		return newArrayContainer(res)
+	}
+	aw, bw := c.words(), other.words()
+	words := make([]uint64, bitmapWords)
+	for i := range words {
+		words[i] = aw[i] &^ bw[i]
+	}
+	return newContainerFromWords(words)
+}
+
+// searchUint16 返回 s 中第一个大于等于 x 的下标.
+func searchUint16(s []uint16, x uint16) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if s[mid] < x {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
@@ -0,0 +1,285 @@
+package set
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roaringTestValues 生成同时覆盖数组, 位图和 run 容器的测试数据.
+func roaringTestValues(r *rand.Rand) []uint32 {
+	var vals []uint32
+	// 稀疏: 数组容器
+	for i := 0; i < 100; i++ {
+		vals = append(vals, uint32(r.Intn(1<<16)))
+	}
+	// 稠密: 位图容器
+	for i := 0; i < 10000; i++ {
+		vals = append(vals, 1<<16|uint32(r.Intn(1<<16)))
+	}
+	// 连续区间: run 容器
+	for i := uint32(0); i < 3000; i++ {
+		vals = append(vals, 5<<16|(100+i))
+	}
+	// 高位
+	vals = append(vals, 0xFFFFFFFF, 0xFFFF0000)
+	return vals
+}
+
+func uniqueSorted(vals []uint32) []uint32 {
+	m := make(map[uint32]struct{}, len(vals))
+	for _, v := range vals {
+		m[v] = struct{}{}
+	}
+	res := make([]uint32, 0, len(m))
+	for v := range m {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+func TestRoaringBitmap_AddRemove(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vals := roaringTestValues(r)
+	want := uniqueSorted(vals)
+
+	rb := NewRoaringBitmap(vals...)
+	assert.Equal(t, want, rb.ToArray())
+	assert.Equal(t, uint64(len(want)), rb.Cardinality())
+	for _, v := range want {
+		assert.True(t, rb.Contains(v))
+	}
+	assert.False(t, rb.Contains(2<<16))
+
+	// 删除后位图容器应该转换回数组容器, 空容器应该被移除
+	for _, v := range want {
+		rb.Remove(v)
+	}
+	assert.True(t, rb.IsEmpty())
+	assert.Equal(t, 0, len(rb.keys))
+}
+
+func TestRoaringBitmap_ContainerType(t *testing.T) {
+	rb := NewRoaringBitmap()
+	for i := uint32(0); i < arrayMaxSize; i++ {
+		rb.Add(i * 2)
+	}
+	assert.Equal(t, arrayContainer, rb.containers[0].typ)
+	rb.Add(1)
+	assert.Equal(t, bitmapContainer, rb.containers[0].typ)
+	rb.Remove(1)
+	assert.Equal(t, arrayContainer, rb.containers[0].typ)
+
+	rb = NewRoaringBitmap()
+	for i := uint32(0); i < 1000; i++ {
+		rb.Add(i)
+	}
+	rb.RunOptimize()
+	assert.Equal(t, runContainer, rb.containers[0].typ)
+	assert.Equal(t, []interval16{{start: 0, last: 999}}, rb.containers[0].runs)
+
+	// 修改 run 容器后依然正确
+	rb.Add(2000)
+	rb.Remove(500)
+	assert.Equal(t, uint64(1000), rb.Cardinality())
+	assert.False(t, rb.Contains(500))
+	assert.True(t, rb.Contains(2000))
+
+	// run 容器更大时不转换
+	rb = NewRoaringBitmap(1, 3, 5)
+	rb.RunOptimize()
+	assert.Equal(t, arrayContainer, rb.containers[0].typ)
+}
+
+func TestRoaringBitmap_RankSelect(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	want := uniqueSorted(roaringTestValues(r))
+	rb := NewRoaringBitmap(want...)
+	runOptimized := rb.Clone()
+	runOptimized.RunOptimize()
+
+	for _, b := range []*RoaringBitmap{rb, runOptimized} {
+		for i, v := range want {
+			assert.Equal(t, uint64(i+1), b.Rank(v))
+			got, ok := b.Select(uint64(i))
+			assert.True(t, ok)
+			assert.Equal(t, v, got)
+		}
+		_, ok := b.Select(uint64(len(want)))
+		assert.False(t, ok)
+		assert.Equal(t, uint64(0), NewRoaringBitmap(1).Rank(0))
+		assert.Equal(t, uint64(len(want)), b.Rank(0xFFFFFFFF))
+
+		minVal, ok := b.Min()
+		assert.True(t, ok)
+		assert.Equal(t, want[0], minVal)
+		maxVal, ok := b.Max()
+		assert.True(t, ok)
+		assert.Equal(t, want[len(want)-1], maxVal)
+	}
+
+	_, ok := NewRoaringBitmap().Min()
+	assert.False(t, ok)
+	_, ok = NewRoaringBitmap().Max()
+	assert.False(t, ok)
+}
+
+func TestRoaringBitmap_Each(t *testing.T) {
+	rb := NewRoaringBitmap(1, 2, 70000, 80000)
+	var got []uint32
+	rb.Each(func(x uint32) bool {
+		got = append(got, x)
+		return x < 70000
+	})
+	assert.Equal(t, []uint32{1, 2, 70000}, got)
+}
+
+func TestRoaringBitmap_Operations(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	a := roaringTestValues(r)
+	b := roaringTestValues(r)
+	ma, mb := make(MapSet[uint32]), make(MapSet[uint32])
+	for _, v := range a {
+		ma.Add(v)
+	}
+	for _, v := range b {
+		mb.Add(v)
+	}
+	toSorted := func(s MapSet[uint32]) []uint32 {
+		return uniqueSorted(s.Keys())
+	}
+
+	ra, rb := NewRoaringBitmap(a...), NewRoaringBitmap(b...)
+	optimized := rb.Clone()
+	optimized.RunOptimize()
+	for _, other := range []*RoaringBitmap{rb, optimized} {
+		assert.Equal(t, toSorted(ma.Intersection(mb)), ra.And(other).ToArray())
+		assert.Equal(t, toSorted(ma.Union(mb)), ra.Or(other).ToArray())
+		assert.Equal(t, toSorted(ma.SymmetricDifference(mb)), ra.Xor(other).ToArray())
+		assert.Equal(t, toSorted(ma.Difference(mb)), ra.AndNot(other).ToArray())
+		assert.Equal(t, toSorted(mb.Difference(ma)), other.AndNot(ra).ToArray())
+	}
+
+	// 运算不会修改参数
+	assert.Equal(t, uniqueSorted(a), ra.ToArray())
+	assert.True(t, rb.Equal(optimized))
+	assert.False(t, ra.Equal(rb))
+	assert.True(t, ra.Xor(ra).IsEmpty())
+}
+
+func TestRoaringBitmap_MarshalBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		rb   func() *RoaringBitmap
+		want []byte
+	}{
+		{
+			name: "empty",
+			rb:   func() *RoaringBitmap { return NewRoaringBitmap() },
+			want: []byte{
+				0x3A, 0x30, 0, 0, // cookie 12346
+				0, 0, 0, 0, // 容器数量
+			},
+		},
+		{
+			name: "array",
+			rb:   func() *RoaringBitmap { return NewRoaringBitmap(1, 2, 3) },
+			want: []byte{
+				0x3A, 0x30, 0, 0, // cookie 12346
+				1, 0, 0, 0, // 容器数量
+				0, 0, 2, 0, // key 0, cardinality-1 = 2
+				16, 0, 0, 0, // 偏移量
+				1, 0, 2, 0, 3, 0,
+			},
+		},
+		{
+			name: "run",
+			rb: func() *RoaringBitmap {
+				rb := NewRoaringBitmap()
+				for i := uint32(1); i <= 100; i++ {
+					rb.Add(i)
+				}
+				rb.RunOptimize()
+				return rb
+			},
+			want: []byte{
+				0x3B, 0x30, 0, 0, // cookie 12347, 容器数量-1 = 0
+				1,           // run 容器标识
+				0, 0, 99, 0, // key 0, cardinality-1 = 99
+				// 容器数量小于4时没有偏移量
+				1, 0, // run 的个数
+				1, 0, 99, 0, // start 1, length-1 = 99
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := tt.rb()
+			data, err := rb.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, data)
+
+			got := NewRoaringBitmap()
+			require.NoError(t, got.UnmarshalBinary(data))
+			assert.Equal(t, rb.ToArray(), got.ToArray())
+		})
+	}
+}
+
+func TestRoaringBitmap_MarshalBinaryRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	rb := NewRoaringBitmap(roaringTestValues(r)...)
+	// 添加足够多的容器以覆盖包含 run 容器时写入偏移量的情况
+	for i := uint32(10); i < 20; i++ {
+		rb.Add(i<<16 | i)
+	}
+	for _, optimize := range []bool{false, true} {
+		if optimize {
+			rb.RunOptimize()
+		}
+		data, err := rb.MarshalBinary()
+		require.NoError(t, err)
+		got := NewRoaringBitmap()
+		require.NoError(t, got.UnmarshalBinary(data))
+		assert.True(t, rb.Equal(got))
+		for i, c := range got.containers {
+			assert.Equal(t, rb.containers[i].typ, c.typ)
+		}
+	}
+}
+
+func TestRoaringBitmap_UnmarshalBinaryInvalid(t *testing.T) {
+	valid, err := NewRoaringBitmap(1, 2, 3).MarshalBinary()
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too_short", data: []byte{0x3A}},
+		{name: "unknown_cookie", data: []byte{1, 2, 3, 4, 0, 0, 0, 0}},
+		{name: "truncated", data: valid[:len(valid)-1]},
+		{name: "too_many_containers", data: []byte{0x3A, 0x30, 0, 0, 0xFF, 0xFF, 0, 0}},
+		{name: "unsorted_array", data: append(append([]byte(nil), valid[:16]...), 3, 0, 2, 0, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, errInvalidRoaringData, NewRoaringBitmap().UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+func TestRoaringBitmap_AsSet(t *testing.T) {
+	rb := NewRoaringBitmap()
+	s := rb.AsSet()
+	s.Add(70000)
+	s.Add(1)
+	assert.True(t, rb.Contains(70000))
+	assert.Equal(t, []uint32{1, 70000}, s.Keys())
+	s.Delete(1)
+	assert.False(t, s.Exists(1))
+	assert.Equal(t, 1, length(s))
+}
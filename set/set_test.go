@@ -0,0 +1,153 @@
+package set_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/udugong/ukit/iterx"
+	"github.com/udugong/ukit/set"
+	"github.com/udugong/ukit/set/settest"
+)
+
+// TestSetConformance 所有 Set 的实现都应该满足相同的行为.
+func TestSetConformance(t *testing.T) {
+	genInt := func(r *rand.Rand) int { return r.Int() }
+	t.Run("map_set", func(t *testing.T) {
+		settest.Run(t, func() set.Set[int] { return set.New[int](0) }, genInt)
+	})
+	t.Run("map_set_string", func(t *testing.T) {
+		settest.Run(t, func() set.Set[string] { return set.New[string](0) },
+			func(r *rand.Rand) string { return strconv.Itoa(r.Int()) })
+	})
+	t.Run("concurrent_set", func(t *testing.T) {
+		settest.Run(t, func() set.Set[int] { return set.NewConcurrentSet[int](0) }, genInt)
+	})
+	t.Run("linked_set", func(t *testing.T) {
+		settest.Run(t, func() set.Set[int] { return set.NewLinkedSet[int](0) }, genInt)
+	})
+	t.Run("tree_set", func(t *testing.T) {
+		settest.Run(t, func() set.Set[int] { return set.NewTreeSet[int]() }, genInt)
+	})
+	t.Run("bit_set", func(t *testing.T) {
+		// BitSet 按照最大的元素分配内存, 所以限制元素的范围
+		settest.Run(t, func() set.Set[uint] { return set.NewBitSet(0).AsSet() },
+			func(r *rand.Rand) uint { return uint(r.Intn(1 << 12)) })
+	})
+	t.Run("roaring_bitmap", func(t *testing.T) {
+		settest.Run(t, func() set.Set[uint32] { return set.NewRoaringBitmap().AsSet() },
+			func(r *rand.Rand) uint32 { return r.Uint32() })
+	})
+	t.Run("sharded_set", func(t *testing.T) {
+		settest.Run(t, func() set.Set[int] {
+			return set.NewShardedSet[int](4, func(key int) uint64 { return uint64(key) })
+		}, genInt)
+	})
+}
+
+// TestAll 所有集合的 All 方法都应该迭代全部元素, 并且可以提前停止.
+func TestAll(t *testing.T) {
+	keys := []int{5, 3, 1, 4, 9}
+	tests := []struct {
+		name    string
+		all     func() iterx.Seq[int]
+		ordered []int // 不为 nil 时要求按照该顺序迭代
+	}{
+		{
+			name: "map_set",
+			all: func() iterx.Seq[int] {
+				s := set.New[int](0)
+				addAll[int](s, keys)
+				return s.All()
+			},
+		},
+		{
+			name: "concurrent_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewConcurrentSet[int](0)
+				addAll[int](s, keys)
+				return s.All()
+			},
+		},
+		{
+			name: "sharded_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewShardedSet[int](4, func(key int) uint64 { return uint64(key) })
+				addAll[int](s, keys)
+				return s.All()
+			},
+		},
+		{
+			name: "linked_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewLinkedSet[int](0)
+				addAll[int](s, keys)
+				return s.All()
+			},
+			ordered: keys,
+		},
+		{
+			name: "tree_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewTreeSet[int]()
+				addAll[int](s, keys)
+				return s.All()
+			},
+			ordered: []int{1, 3, 4, 5, 9},
+		},
+		{
+			name: "bit_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewBitSet(0)
+				for _, key := range keys {
+					s.Set(uint(key))
+				}
+				return iterx.Map(s.All(), func(v uint) int { return int(v) })
+			},
+			ordered: []int{1, 3, 4, 5, 9},
+		},
+		{
+			name: "roaring_bitmap",
+			all: func() iterx.Seq[int] {
+				s := set.NewRoaringBitmap()
+				for _, key := range keys {
+					s.Add(uint32(key))
+				}
+				return iterx.Map(s.All(), func(v uint32) int { return int(v) })
+			},
+			ordered: []int{1, 3, 4, 5, 9},
+		},
+		{
+			name: "multi_set",
+			all: func() iterx.Seq[int] {
+				s := set.NewMultiSet[int](0)
+				for _, key := range keys {
+					s.Add(key, 2)
+				}
+				return iterx.Map(s.All(), func(e set.Element[int]) int {
+					assert.Equal(t, 2, e.Count)
+					return e.Key
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := iterx.Collect(tt.all())
+			if tt.ordered != nil {
+				assert.Equal(t, tt.ordered, got)
+			} else {
+				assert.ElementsMatch(t, keys, got)
+			}
+			assert.Len(t, iterx.Collect(iterx.Take(tt.all(), 2)), 2)
+		})
+	}
+}
+
+func addAll[T comparable](s set.Set[T], keys []T) {
+	for _, key := range keys {
+		s.Add(key)
+	}
+}
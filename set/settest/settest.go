@@ -0,0 +1,115 @@
+// Package settest 提供 set.Set 实现的通用测试套件.
+// 任何 set.Set 的实现都可以通过 Run 检查是否满足相同的行为约定.
+package settest
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/set"
+)
+
+// poolSize 测试中使用的不同元素的个数.
+// 取值范围较小以便覆盖重复添加和删除.
+const poolSize = 64
+
+// Run 对 newSet 创建的集合运行完整的行为测试和基于性质的随机测试.
+// newSet 每次调用都必须返回一个新的空集合.
+// gen 用于生成随机元素, 必须能够生成至少 64 个不同的值.
+func Run[T comparable](t *testing.T, newSet func() set.Set[T], gen func(r *rand.Rand) T) {
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	keys := distinct(t, rand.New(rand.NewSource(seed)), gen)
+
+	t.Run("empty", func(t *testing.T) {
+		s := newSet()
+		assert.False(t, s.Exists(keys[0]))
+		assert.Empty(t, s.Keys())
+	})
+	t.Run("add", func(t *testing.T) {
+		s := newSet()
+		for _, key := range []T{keys[0], keys[1], keys[2], keys[0], keys[1]} {
+			s.Add(key)
+		}
+		for _, key := range keys[:3] {
+			assert.True(t, s.Exists(key))
+		}
+		assert.False(t, s.Exists(keys[3]))
+		assert.ElementsMatch(t, keys[:3], s.Keys())
+	})
+	t.Run("delete", func(t *testing.T) {
+		s := newSet()
+		s.Add(keys[0])
+		s.Add(keys[1])
+		s.Delete(keys[0])
+		s.Delete(keys[2]) // 删除不存在的元素不会有任何影响
+		assert.False(t, s.Exists(keys[0]))
+		assert.True(t, s.Exists(keys[1]))
+		assert.ElementsMatch(t, keys[1:2], s.Keys())
+		s.Delete(keys[1])
+		assert.Empty(t, s.Keys())
+	})
+	t.Run("keys_is_copy", func(t *testing.T) {
+		s := newSet()
+		s.Add(keys[0])
+		got := s.Keys()
+		got[0] = keys[1]
+		assert.True(t, s.Exists(keys[0]))
+		assert.False(t, s.Exists(keys[1]))
+	})
+	t.Run("random_ops", func(t *testing.T) {
+		testRandomOps(t, newSet, keys, seed)
+	})
+}
+
+// distinct 使用 gen 生成 poolSize 个不同的元素.
+func distinct[T comparable](t *testing.T, r *rand.Rand, gen func(r *rand.Rand) T) []T {
+	seen := make(map[T]struct{}, poolSize)
+	keys := make([]T, 0, poolSize)
+	for i := 0; len(keys) < poolSize; i++ {
+		require.Less(t, i, 100*poolSize, "gen 无法生成足够多的不同元素")
+		key := gen(r)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// testRandomOps 随机执行添加和删除, 并与 map 实现的参考模型比较结果.
+func testRandomOps[T comparable](t *testing.T, newSet func() set.Set[T], keys []T, seed int64) {
+	const (
+		rounds = 20
+		ops    = 500
+	)
+	r := rand.New(rand.NewSource(seed))
+	for round := 0; round < rounds; round++ {
+		s := newSet()
+		model := make(map[T]struct{})
+		for op := 0; op < ops; op++ {
+			key := keys[r.Intn(len(keys))]
+			switch r.Intn(3) {
+			case 0:
+				s.Add(key)
+				model[key] = struct{}{}
+			case 1:
+				s.Delete(key)
+				delete(model, key)
+			default:
+				_, want := model[key]
+				require.Equalf(t, want, s.Exists(key), "seed %d, round %d, op %d", seed, round, op)
+			}
+		}
+		want := make([]T, 0, len(model))
+		for key := range model {
+			want = append(want, key)
+		}
+		require.ElementsMatchf(t, want, s.Keys(), "seed %d, round %d", seed, round)
+	}
+}
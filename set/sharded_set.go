@@ -0,0 +1,95 @@
+package set
+
+import "github.com/udugong/ukit/iterx"
+
+// ShardedSet 分片的并发安全集合.
+// 元素根据哈希值分散到多个 ConcurrentSet 中, 以降低热点集合的锁竞争.
+type ShardedSet[T comparable] struct {
+	shards []*ConcurrentSet[T]
+	mask   uint64
+	hash   func(key T) uint64
+}
+
+// NewShardedSet 创建一个分片集合.
+// shards 为分片数量, 会向上取整为2的幂, 必须大于0 否则会 panic.
+// hash 用于计算元素所在的分片, 分布越均匀锁竞争越少, 为 nil 时会 panic.
+func NewShardedSet[T comparable](shards int, hash func(key T) uint64) *ShardedSet[T] {
+	if shards < 1 {
+		panic("ukit: 分片数量必须为正数")
+	}
+	if hash == nil {
+		panic("ukit: 哈希函数不能为 nil")
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	s := &ShardedSet[T]{
+		shards: make([]*ConcurrentSet[T], n),
+		mask:   uint64(n - 1),
+		hash:   hash,
+	}
+	for i := range s.shards {
+		s.shards[i] = NewConcurrentSet[T](0)
+	}
+	return s
+}
+
+func (s *ShardedSet[T]) shard(key T) *ConcurrentSet[T] {
+	return s.shards[s.hash(key)&s.mask]
+}
+
+func (s *ShardedSet[T]) Add(key T) {
+	s.shard(key).Add(key)
+}
+
+func (s *ShardedSet[T]) Delete(key T) {
+	s.shard(key).Delete(key)
+}
+
+func (s *ShardedSet[T]) Exists(key T) bool {
+	return s.shard(key).Exists(key)
+}
+
+// Keys 返回所有分片中的元素.
+// 各个分片是分别加锁的, 所以结果不是整个集合在某一时刻的快照.
+func (s *ShardedSet[T]) Keys() []T {
+	ans := make([]T, 0, s.Len())
+	for _, shard := range s.shards {
+		ans = append(ans, shard.Keys()...)
+	}
+	return ans
+}
+
+// Len 返回集合中元素的个数.
+// 与 Keys 一样, 结果不是整个集合在某一时刻的快照.
+func (s *ShardedSet[T]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// AddIfAbsent 如果元素不存在则添加, 返回集合是否发生了变化.
+func (s *ShardedSet[T]) AddIfAbsent(key T) bool {
+	return s.shard(key).AddIfAbsent(key)
+}
+
+// DeleteIfPresent 如果元素存在则删除, 返回集合是否发生了变化.
+func (s *ShardedSet[T]) DeleteIfPresent(key T) bool {
+	return s.shard(key).DeleteIfPresent(key)
+}
+
+// All 依次迭代每个分片中元素的快照, 顺序是不确定的. 迭代过程中可以修改集合.
+func (s *ShardedSet[T]) All() iterx.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, shard := range s.shards {
+			for _, key := range shard.Keys() {
+				if !yield(key) {
+					return
+				}
+			}
+		}
+	}
+}
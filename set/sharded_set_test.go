@@ -0,0 +1,83 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intHash(key int) uint64 {
+	return uint64(key)
+}
+
+func TestNewShardedSet(t *testing.T) {
+	tests := []struct {
+		name       string
+		shards     int
+		hash       func(key int) uint64
+		wantShards int
+		wantPanic  bool
+	}{
+		{
+			name:       "power_of_two",
+			shards:     4,
+			hash:       intHash,
+			wantShards: 4,
+		},
+		{
+			name:       "round_up",
+			shards:     5,
+			hash:       intHash,
+			wantShards: 8,
+		},
+		{
+			name:      "shards_less_than_1",
+			shards:    0,
+			hash:      intHash,
+			wantPanic: true,
+		},
+		{
+			name:      "nil_hash",
+			shards:    4,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPanic {
+				assert.Panics(t, func() { NewShardedSet[int](tt.shards, tt.hash) })
+				return
+			}
+			s := NewShardedSet[int](tt.shards, tt.hash)
+			assert.Equal(t, tt.wantShards, len(s.shards))
+		})
+	}
+}
+
+func TestShardedSet_Distribute(t *testing.T) {
+	s := NewShardedSet[int](4, intHash)
+	for i := 0; i < 8; i++ {
+		s.Add(i)
+	}
+	for _, shard := range s.shards {
+		assert.Equal(t, 2, shard.Len())
+	}
+	assert.Equal(t, 8, s.Len())
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, s.Keys())
+}
+
+func TestShardedSet_AddIfAbsent(t *testing.T) {
+	s := NewShardedSet[int](4, intHash)
+	assert.True(t, s.AddIfAbsent(1))
+	assert.False(t, s.AddIfAbsent(1))
+	assert.True(t, s.DeleteIfPresent(1))
+	assert.False(t, s.DeleteIfPresent(1))
+}
+
+func TestShardedSet_Concurrent(t *testing.T) {
+	testConcurrentSet(t, NewShardedSet[int](8, intHash))
+}
+
+func BenchmarkShardedSet(b *testing.B) {
+	benchmarkConcurrentSet(b, NewShardedSet[int](16, intHash))
+}
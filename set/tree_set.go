@@ -0,0 +1,321 @@
+package set
+
+import (
+	"golang.org/x/exp/constraints"
+
+	"github.com/udugong/ukit/iterx"
+)
+
+// treeNode AVL 树节点.
+type treeNode[T comparable] struct {
+	key         T
+	left, right *treeNode[T]
+	height      int
+}
+
+// TreeSet 基于 AVL 树实现的有序集合.
+// Add, Delete 和 Exists 的时间复杂度都是 O(log n).
+type TreeSet[T comparable] struct {
+	root    *treeNode[T]
+	length  int
+	compare func(a, b T) int
+}
+
+// NewTreeSet 创建一个按照元素升序排列的有序集合.
+func NewTreeSet[T constraints.Ordered]() *TreeSet[T] {
+	return NewTreeSetFunc[T](func(a, b T) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// NewTreeSetFunc 创建一个使用 compare 比较元素的有序集合.
+// compare(a, b) 在 a < b 时返回负数, a > b 时返回正数, 相等时返回0.
+// compare 认为相等的元素必须是同一个元素.
+func NewTreeSetFunc[T comparable](compare func(a, b T) int) *TreeSet[T] {
+	return &TreeSet[T]{compare: compare}
+}
+
+func (s *TreeSet[T]) Add(key T) {
+	var added bool
+	s.root, added = s.insert(s.root, key)
+	if added {
+		s.length++
+	}
+}
+
+func (s *TreeSet[T]) Delete(key T) {
+	var removed bool
+	s.root, removed = s.remove(s.root, key)
+	if removed {
+		s.length--
+	}
+}
+
+func (s *TreeSet[T]) Exists(key T) bool {
+	node := s.root
+	for node != nil {
+		c := s.compare(key, node.key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Keys 按照升序返回所有元素.
+func (s *TreeSet[T]) Keys() []T {
+	ans := make([]T, 0, s.length)
+	s.Each(func(key T) bool {
+		ans = append(ans, key)
+		return true
+	})
+	return ans
+}
+
+// Len 返回集合中元素的个数.
+func (s *TreeSet[T]) Len() int {
+	return s.length
+}
+
+// Min 返回最小的元素.
+// 如果集合为空则返回 false.
+func (s *TreeSet[T]) Min() (T, bool) {
+	if s.root == nil {
+		var t T
+		return t, false
+	}
+	node := s.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.key, true
+}
+
+// Max 返回最大的元素.
+// 如果集合为空则返回 false.
+func (s *TreeSet[T]) Max() (T, bool) {
+	if s.root == nil {
+		var t T
+		return t, false
+	}
+	node := s.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.key, true
+}
+
+// Floor 返回小于等于 key 的最大元素.
+// 如果不存在则返回 false.
+func (s *TreeSet[T]) Floor(key T) (T, bool) {
+	var res *treeNode[T]
+	node := s.root
+	for node != nil {
+		c := s.compare(key, node.key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			res = node
+			node = node.right
+		default:
+			return node.key, true
+		}
+	}
+	if res == nil {
+		var t T
+		return t, false
+	}
+	return res.key, true
+}
+
+// Ceiling 返回大于等于 key 的最小元素.
+// 如果不存在则返回 false.
+func (s *TreeSet[T]) Ceiling(key T) (T, bool) {
+	var res *treeNode[T]
+	node := s.root
+	for node != nil {
+		c := s.compare(key, node.key)
+		switch {
+		case c < 0:
+			res = node
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node.key, true
+		}
+	}
+	if res == nil {
+		var t T
+		return t, false
+	}
+	return res.key, true
+}
+
+// Range 按照升序返回在闭区间 [lo, hi] 内的元素.
+func (s *TreeSet[T]) Range(lo, hi T) []T {
+	var ans []T
+	s.rangeNode(s.root, lo, hi, &ans)
+	return ans
+}
+
+func (s *TreeSet[T]) rangeNode(node *treeNode[T], lo, hi T, ans *[]T) {
+	if node == nil {
+		return
+	}
+	cl, ch := s.compare(node.key, lo), s.compare(node.key, hi)
+	if cl > 0 {
+		s.rangeNode(node.left, lo, hi, ans)
+	}
+	if cl >= 0 && ch <= 0 {
+		*ans = append(*ans, node.key)
+	}
+	if ch < 0 {
+		s.rangeNode(node.right, lo, hi, ans)
+	}
+}
+
+// Each 按照升序遍历元素, fn 返回 false 时停止遍历.
+// 遍历过程中不能修改集合.
+func (s *TreeSet[T]) Each(fn func(key T) bool) {
+	// 使用栈进行中序遍历, 树高为 O(log n)
+	stack := make([]*treeNode[T], 0, s.height(s.root))
+	node := s.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !fn(node.key) {
+			return
+		}
+		node = node.right
+	}
+}
+
+func (s *TreeSet[T]) insert(node *treeNode[T], key T) (*treeNode[T], bool) {
+	if node == nil {
+		return &treeNode[T]{key: key, height: 1}, true
+	}
+	var added bool
+	c := s.compare(key, node.key)
+	switch {
+	case c < 0:
+		node.left, added = s.insert(node.left, key)
+	case c > 0:
+		node.right, added = s.insert(node.right, key)
+	default:
+		return node, false
+	}
+	if !added {
+		return node, false
+	}
+	return s.balance(node), true
+}
+
+func (s *TreeSet[T]) remove(node *treeNode[T], key T) (*treeNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var removed bool
+	c := s.compare(key, node.key)
+	switch {
+	case c < 0:
+		node.left, removed = s.remove(node.left, key)
+	case c > 0:
+		node.right, removed = s.remove(node.right, key)
+	default:
+		if node.left == nil {
+			return node.right, true
+		}
+		if node.right == nil {
+			return node.left, true
+		}
+		// 使用右子树的最小节点替换当前节点
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.key = successor.key
+		node.right, _ = s.remove(node.right, successor.key)
+		removed = true
+	}
+	if !removed {
+		return node, false
+	}
+	return s.balance(node), true
+}
+
+func (s *TreeSet[T]) height(node *treeNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (s *TreeSet[T]) updateHeight(node *treeNode[T]) {
+	l, r := s.height(node.left), s.height(node.right)
+	if l > r {
+		node.height = l + 1
+	} else {
+		node.height = r + 1
+	}
+}
+
+// balance 更新节点高度并通过旋转恢复平衡.
+func (s *TreeSet[T]) balance(node *treeNode[T]) *treeNode[T] {
+	s.updateHeight(node)
+	factor := s.height(node.left) - s.height(node.right)
+	switch {
+	case factor > 1:
+		if s.height(node.left.left) < s.height(node.left.right) {
+			node.left = s.rotateLeft(node.left)
+		}
+		return s.rotateRight(node)
+	case factor < -1:
+		if s.height(node.right.right) < s.height(node.right.left) {
+			node.right = s.rotateRight(node.right)
+		}
+		return s.rotateLeft(node)
+	default:
+		return node
+	}
+}
+
+func (s *TreeSet[T]) rotateLeft(node *treeNode[T]) *treeNode[T] {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	s.updateHeight(node)
+	s.updateHeight(right)
+	return right
+}
+
+func (s *TreeSet[T]) rotateRight(node *treeNode[T]) *treeNode[T] {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	s.updateHeight(node)
+	s.updateHeight(left)
+	return left
+}
+
+// All 按照升序迭代元素. 迭代过程中不能修改集合.
+func (s *TreeSet[T]) All() iterx.Seq[T] {
+	return s.Each
+}
@@ -0,0 +1,206 @@
+package set
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTreeSet(keys ...int) *TreeSet[int] {
+	s := NewTreeSet[int]()
+	for _, key := range keys {
+		s.Add(key)
+	}
+	return s
+}
+
+// verify 检查 AVL 树的有序性和平衡性, 返回子树高度.
+func (s *TreeSet[T]) verify(t *testing.T, node *treeNode[T]) int {
+	t.Helper()
+	if node == nil {
+		return 0
+	}
+	if node.left != nil {
+		assert.Negative(t, s.compare(node.left.key, node.key))
+	}
+	if node.right != nil {
+		assert.Positive(t, s.compare(node.right.key, node.key))
+	}
+	l, r := s.verify(t, node.left), s.verify(t, node.right)
+	assert.LessOrEqual(t, l-r, 1)
+	assert.GreaterOrEqual(t, l-r, -1)
+	h := l + 1
+	if r > l {
+		h = r + 1
+	}
+	assert.Equal(t, h, node.height)
+	return h
+}
+
+func TestTreeSet_Balance(t *testing.T) {
+	s := NewTreeSet[int]()
+	r := rand.New(rand.NewSource(1))
+	model := make(map[int]struct{})
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			s.Delete(key)
+			delete(model, key)
+		} else {
+			s.Add(key)
+			model[key] = struct{}{}
+		}
+	}
+	s.verify(t, s.root)
+	want := make([]int, 0, len(model))
+	for key := range model {
+		want = append(want, key)
+	}
+	sort.Ints(want)
+	assert.Equal(t, want, s.Keys())
+	assert.Equal(t, len(want), s.Len())
+
+	// 顺序插入是 AVL 树的最坏情况
+	s = NewTreeSet[int]()
+	for i := 0; i < 1024; i++ {
+		s.Add(i)
+	}
+	s.verify(t, s.root)
+	assert.Equal(t, 11, s.root.height)
+}
+
+func TestTreeSet_MinMax(t *testing.T) {
+	s := NewTreeSet[int]()
+	_, ok := s.Min()
+	assert.False(t, ok)
+	_, ok = s.Max()
+	assert.False(t, ok)
+
+	s = newTreeSet(5, 3, 8, 1)
+	got, ok := s.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+	got, ok = s.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 8, got)
+}
+
+func TestTreeSet_FloorCeiling(t *testing.T) {
+	s := newTreeSet(10, 20, 30)
+	tests := []struct {
+		name        string
+		key         int
+		wantFloor   int
+		wantFloorOk bool
+		wantCeil    int
+		wantCeilOk  bool
+	}{
+		{
+			name:       "below_min",
+			key:        5,
+			wantCeil:   10,
+			wantCeilOk: true,
+		},
+		{
+			name:        "exact",
+			key:         20,
+			wantFloor:   20,
+			wantFloorOk: true,
+			wantCeil:    20,
+			wantCeilOk:  true,
+		},
+		{
+			name:        "between",
+			key:         25,
+			wantFloor:   20,
+			wantFloorOk: true,
+			wantCeil:    30,
+			wantCeilOk:  true,
+		},
+		{
+			name:        "above_max",
+			key:         35,
+			wantFloor:   30,
+			wantFloorOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Floor(tt.key)
+			assert.Equal(t, tt.wantFloorOk, ok)
+			assert.Equal(t, tt.wantFloor, got)
+			got, ok = s.Ceiling(tt.key)
+			assert.Equal(t, tt.wantCeilOk, ok)
+			assert.Equal(t, tt.wantCeil, got)
+		})
+	}
+}
+
+func TestTreeSet_Range(t *testing.T) {
+	s := newTreeSet(1, 3, 5, 7, 9)
+	tests := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{
+			name: "inclusive",
+			lo:   3,
+			hi:   7,
+			want: []int{3, 5, 7},
+		},
+		{
+			name: "between_keys",
+			lo:   2,
+			hi:   8,
+			want: []int{3, 5, 7},
+		},
+		{
+			name: "all",
+			lo:   0,
+			hi:   10,
+			want: []int{1, 3, 5, 7, 9},
+		},
+		{
+			name: "empty",
+			lo:   10,
+			hi:   20,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Range(tt.lo, tt.hi))
+		})
+	}
+}
+
+func TestTreeSet_Each(t *testing.T) {
+	s := newTreeSet(3, 1, 2, 5, 4)
+	var got []int
+	s.Each(func(key int) bool {
+		got = append(got, key)
+		return key < 3
+	})
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
+
+func TestNewTreeSetFunc(t *testing.T) {
+	// 降序
+	s := NewTreeSetFunc[string](func(a, b string) int {
+		switch {
+		case a > b:
+			return -1
+		case a < b:
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, key := range []string{"b", "a", "c"} {
+		s.Add(key)
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, s.Keys())
+}
@@ -1,5 +1,22 @@
 package slicex
 
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+
+	"github.com/udugong/ukit/internal/errs"
+)
+
+// number 整数或浮点数.
+type number interface {
+	constraints.Integer | constraints.Float
+}
+
 func ConvToAny[S ~[]E, E any](s S) []any {
 	data := make([]any, 0, len(s))
 	for k := range s {
@@ -7,3 +24,164 @@ func ConvToAny[S ~[]E, E any](s S) []any {
 	}
 	return data
 }
+
+// ConvFromAny 将 src 中的元素断言为 T.
+// 遇到第一个类型不匹配的元素时返回错误, 错误中包含该元素的下标.
+// nil 元素无法断言为任何类型, 包括接口类型.
+func ConvFromAny[T any](src []any) ([]T, error) {
+	res := make([]T, 0, len(src))
+	for i, v := range src {
+		t, ok := v.(T)
+		if !ok {
+			return nil, errs.NewErrInvalidType(i, typeName[T](), v)
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// ConvNumber 将 src 中的数值转换为 To 类型.
+// 数值超出 To 的范围时返回错误, 错误中包含该元素的下标.
+// 浮点数转换为整数时与 Go 的类型转换一样舍弃小数部分, NaN 视为超出范围;
+// 转换为浮点数时允许损失精度, 但有限值不能变为无穷大.
+func ConvNumber[To, From number](src []From) ([]To, error) {
+	res := make([]To, 0, len(src))
+	for i, v := range src {
+		t, ok := convNumber[To](v)
+		if !ok {
+			return nil, errs.NewErrOverflow(i, v, typeName[To]())
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// convNumber 将 v 转换为 To 类型, 超出范围时返回 false.
+func convNumber[To, From number](v From) (To, bool) {
+	if isFloat[To]() {
+		t := To(v)
+		return t, !math.IsInf(float64(t), 0) || math.IsInf(float64(v), 0)
+	}
+	if isFloat[From]() {
+		f := math.Trunc(float64(v))
+		// 先检查范围, 超出范围的浮点数转换为整数的结果是不确定的
+		bits := int(unsafe.Sizeof(To(0))) * 8
+		lo, hi := 0.0, math.Ldexp(1, bits)
+		if isSigned[To]() {
+			lo, hi = -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+		}
+		if math.IsNaN(f) || f < lo || f >= hi {
+			return 0, false
+		}
+		return To(f), true
+	}
+	t := To(v)
+	return t, From(t) == v && (v < 0) == (t < 0)
+}
+
+func isFloat[T number]() bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+func isSigned[T number]() bool {
+	var zero T
+	return zero-1 < 0
+}
+
+// typeName 返回 T 的类型名称, 对接口类型同样有效.
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+// ToStrings 将 src 中的元素转换为字符串.
+// 实现了 fmt.Stringer 的元素使用 String 方法, 基本类型使用 strconv, 其它类型使用 fmt.Sprint.
+func ToStrings[S ~[]E, E any](src S) []string {
+	res := make([]string, 0, len(src))
+	for _, v := range src {
+		res = append(res, toString(v))
+	}
+	return res
+}
+
+func toString(v any) string {
+	switch val := v.(type) {
+	case fmt.Stringer:
+		return val.String()
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case int:
+		return strconv.Itoa(val)
+	case int8:
+		return strconv.FormatInt(int64(val), 10)
+	case int16:
+		return strconv.FormatInt(int64(val), 10)
+	case int32:
+		return strconv.FormatInt(int64(val), 10)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case uint:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint64:
+		return strconv.FormatUint(val, 10)
+	case uintptr:
+		return strconv.FormatUint(uint64(val), 10)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ParseInts 将 src 中的十进制字符串解析为 T 类型的整数.
+// 遇到第一个无法解析或超出 T 范围的元素时返回错误, 错误中包含该元素的下标.
+func ParseInts[T constraints.Integer, S ~[]E, E ~string](src S) ([]T, error) {
+	bits := int(unsafe.Sizeof(T(0))) * 8
+	res := make([]T, 0, len(src))
+	for i, s := range src {
+		var (
+			t   T
+			err error
+		)
+		if isSigned[T]() {
+			var v int64
+			v, err = strconv.ParseInt(string(s), 10, bits)
+			t = T(v)
+		} else {
+			var v uint64
+			v, err = strconv.ParseUint(string(s), 10, bits)
+			t = T(v)
+		}
+		if err != nil {
+			return nil, errs.NewErrInvalidElement(i, err)
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// ParseFloats 将 src 中的字符串解析为 T 类型的浮点数.
+// 遇到第一个无法解析或超出 T 范围的元素时返回错误, 错误中包含该元素的下标.
+func ParseFloats[T constraints.Float, S ~[]E, E ~string](src S) ([]T, error) {
+	bits := int(unsafe.Sizeof(T(0))) * 8
+	res := make([]T, 0, len(src))
+	for i, s := range src {
+		v, err := strconv.ParseFloat(string(s), bits)
+		if err != nil {
+			return nil, errs.NewErrInvalidElement(i, err)
+		}
+		res = append(res, T(v))
+	}
+	return res, nil
+}
@@ -1,9 +1,15 @@
 package slicex
 
 import (
+	"fmt"
+	"math"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/internal/errs"
 )
 
 func TestConvToAny(t *testing.T) {
@@ -25,3 +31,168 @@ func TestConvToAny(t *testing.T) {
 		})
 	}
 }
+
+type stringer int
+
+func (s stringer) String() string {
+	return "s" + strconv.Itoa(int(s))
+}
+
+func TestConvFromAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []any
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "normal",
+			src:  []any{"a", "b"},
+			want: []string{"a", "b"},
+		},
+		{
+			name:    "invalid_type",
+			src:     []any{"a", 1, 2},
+			wantErr: errs.NewErrInvalidType(1, "string", 1),
+		},
+		{
+			name:    "nil_element",
+			src:     []any{nil},
+			wantErr: errs.NewErrInvalidType(0, "string", nil),
+		},
+		{
+			name: "empty",
+			src:  []any{},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvFromAny[string](tt.src)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := ConvFromAny[fmt.Stringer]([]any{stringer(1)})
+	require.NoError(t, err)
+	assert.Equal(t, []fmt.Stringer{stringer(1)}, got)
+	_, err = ConvFromAny[fmt.Stringer]([]any{1})
+	assert.Equal(t, errs.NewErrInvalidType(0, "fmt.Stringer", 1), err)
+}
+
+func TestConvNumber(t *testing.T) {
+	t.Run("int64_to_int8", func(t *testing.T) {
+		got, err := ConvNumber[int8]([]int64{-128, 0, 127})
+		require.NoError(t, err)
+		assert.Equal(t, []int8{-128, 0, 127}, got)
+
+		_, err = ConvNumber[int8]([]int64{1, 128})
+		assert.Equal(t, errs.NewErrOverflow(1, int64(128), "int8"), err)
+		_, err = ConvNumber[int8]([]int64{-129})
+		assert.Equal(t, errs.NewErrOverflow(0, int64(-129), "int8"), err)
+	})
+	t.Run("signed_unsigned", func(t *testing.T) {
+		_, err := ConvNumber[uint64]([]int{-1})
+		assert.Equal(t, errs.NewErrOverflow(0, -1, "uint64"), err)
+		_, err = ConvNumber[int64]([]uint64{math.MaxUint64})
+		assert.Equal(t, errs.NewErrOverflow(0, uint64(math.MaxUint64), "int64"), err)
+
+		got, err := ConvNumber[uint8]([]int{0, 255})
+		require.NoError(t, err)
+		assert.Equal(t, []uint8{0, 255}, got)
+	})
+	t.Run("float_to_int", func(t *testing.T) {
+		got, err := ConvNumber[int32]([]float64{1.9, -1.9, -0.5, math.MaxInt32})
+		require.NoError(t, err)
+		assert.Equal(t, []int32{1, -1, 0, math.MaxInt32}, got)
+
+		got8, err := ConvNumber[uint8]([]float32{-0.5, 255.9})
+		require.NoError(t, err)
+		assert.Equal(t, []uint8{0, 255}, got8)
+
+		tests := []struct {
+			name string
+			v    float64
+		}{
+			{name: "too_large", v: math.MaxInt32 + 1},
+			{name: "too_small", v: math.MinInt32 - 1},
+			{name: "nan", v: math.NaN()},
+			{name: "inf", v: math.Inf(1)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := ConvNumber[int32]([]float64{tt.v})
+				assert.Equal(t, errs.NewErrOverflow(0, tt.v, "int32"), err)
+			})
+		}
+
+		_, err = ConvNumber[uint8]([]float64{-1})
+		assert.Equal(t, errs.NewErrOverflow(0, float64(-1), "uint8"), err)
+		_, err = ConvNumber[int64]([]float64{1 << 63})
+		assert.Equal(t, errs.NewErrOverflow(0, float64(1<<63), "int64"), err)
+	})
+	t.Run("to_float", func(t *testing.T) {
+		got, err := ConvNumber[float32]([]float64{1.5, math.Inf(-1)})
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1.5, float32(math.Inf(-1))}, got)
+
+		_, err = ConvNumber[float32]([]float64{math.MaxFloat64})
+		assert.Equal(t, errs.NewErrOverflow(0, math.MaxFloat64, "float32"), err)
+
+		gotF, err := ConvNumber[float64]([]uint64{math.MaxUint64})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{math.MaxUint64}, gotF)
+	})
+}
+
+func TestToStrings(t *testing.T) {
+	assert.Equal(t, []string{"1", "-2"}, ToStrings([]int{1, 2 - 4}))
+	assert.Equal(t, []string{"255"}, ToStrings([]uint8{255}))
+	assert.Equal(t, []string{"0.1", "1e+21"}, ToStrings([]float64{0.1, 1e21}))
+	assert.Equal(t, []string{"0.1"}, ToStrings([]float32{0.1}))
+	assert.Equal(t, []string{"true"}, ToStrings([]bool{true}))
+	assert.Equal(t, []string{"s1", "s2"}, ToStrings([]stringer{1, 2}))
+	assert.Equal(t, []string{"{1 a}"}, ToStrings([]user{{1, "a"}}))
+	assert.Equal(t, []string{"a", "<nil>"}, ToStrings([]any{"a", nil}))
+}
+
+func TestParseInts(t *testing.T) {
+	got, err := ParseInts[int]([]string{"1", "-2", "+3"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, -2, 3}, got)
+
+	got8, err := ParseInts[uint8]([]string{"255"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{255}, got8)
+
+	tests := []struct {
+		name    string
+		src     []string
+		wantErr error
+	}{
+		{name: "syntax", src: []string{"1", "a"}, wantErr: strconv.ErrSyntax},
+		{name: "range", src: []string{"256"}, wantErr: strconv.ErrRange},
+		{name: "negative_unsigned", src: []string{"-1"}, wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseInts[uint8](tt.src)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), fmt.Sprintf("下标 %d", len(tt.src)-1))
+		})
+	}
+}
+
+func TestParseFloats(t *testing.T) {
+	got, err := ParseFloats[float64]([]string{"1.5", "-2", "1e3"})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5, -2, 1000}, got)
+
+	_, err = ParseFloats[float32]([]string{"1", "1e39"})
+	assert.ErrorIs(t, err, strconv.ErrRange)
+	assert.Contains(t, err.Error(), "下标 1")
+
+	_, err = ParseFloats[float64]([]string{"x"})
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
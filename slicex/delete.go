@@ -1,7 +1,146 @@
 package slicex
 
-import "github.com/udugong/ukit/internal/slice"
+import (
+	"github.com/udugong/ukit/internal/errs"
+	"github.com/udugong/ukit/internal/slice"
+	"github.com/udugong/ukit/option"
+)
 
-func Delete[S ~[]E, E any](src S, index int) (S, error) {
-	return slice.DeleteByAppend(src, index)
+// DeleteStrategy 删除单个元素时移动后续元素的方式.
+type DeleteStrategy uint8
+
+const (
+	// DeleteStrategyAppend 使用 append 移动元素, 是 Delete 默认的策略.
+	// internal/slice 的 BenchmarkDeleteStrategy 表明它在各种元素大小和删除位置下
+	// 都与 DeleteStrategyCopy 持平. 逐个赋值的方式需要移动的元素越多越慢, 所以没有提供.
+	DeleteStrategyAppend DeleteStrategy = iota
+	// DeleteStrategyCopy 使用 copy 移动元素.
+	DeleteStrategyCopy
+)
+
+// Delete 删除 index 处的元素.
+// 可以通过 WithStrategy 选择删除策略, 通过 WithShrink 在删除后缩容.
+func Delete[S ~[]E, E any](src S, index int, opts ...DeleteOption) (S, error) {
+	if len(opts) == 0 {
+		return slice.DeleteByAppend(src, index)
+	}
+	var o deleteOptions
+	for _, opt := range opts {
+		opt.Apply(&o)
+	}
+	var (
+		res S
+		err error
+	)
+	switch o.strategy {
+	case DeleteStrategyCopy:
+		res, err = slice.DeleteByCopy(src, index)
+	default:
+		res, err = slice.DeleteByAppend(src, index)
+	}
+	if err != nil {
+		return res, err
+	}
+	if o.shrink {
+		res = ShrinkIfSparse(res, o.shrinkFraction)
+	}
+	return res, nil
+}
+
+type deleteOptions struct {
+	strategy       DeleteStrategy
+	shrink         bool
+	shrinkFraction float64
+}
+
+// DeleteOption Delete 的选项.
+type DeleteOption = option.Option[deleteOptions]
+
+// WithStrategy 设置删除策略, 默认为 DeleteStrategyAppend.
+func WithStrategy(strategy DeleteStrategy) DeleteOption {
+	return option.NewFuncOption[deleteOptions](func(o *deleteOptions) {
+		o.strategy = strategy
+	})
+}
+
+// WithShrink 删除后调用 ShrinkIfSparse(res, fraction) 缩容.
+// 适用于长期持有的切片, 避免删除大量元素后依然占用原来的内存.
+func WithShrink(fraction float64) DeleteOption {
+	return option.NewFuncOption[deleteOptions](func(o *deleteOptions) {
+		o.shrink = true
+		o.shrinkFraction = fraction
+	})
+}
+
+// DeleteRange 删除 src[lo:hi] 中的元素, 并将空出来的尾部元素置为零值.
+func DeleteRange[S ~[]E, E any](src S, lo, hi int) (S, error) {
+	if err := checkRange(len(src), lo, hi); err != nil {
+		return S(nil), err
+	}
+	if lo == hi {
+		return src, nil
+	}
+	length := len(src)
+	src = append(src[:lo], src[hi:]...)
+	zero(src[len(src):length])
+	return src, nil
+}
+
+// DeleteFunc 删除所有 fn 返回 true 的元素, 并将空出来的尾部元素置为零值.
+func DeleteFunc[S ~[]E, E any](src S, fn func(E) bool) S {
+	n := 0
+	for _, v := range src {
+		if !fn(v) {
+			src[n] = v
+			n++
+		}
+	}
+	zero(src[n:])
+	return src[:n]
+}
+
+// DeleteValue 删除所有等于 v 的元素, 并将空出来的尾部元素置为零值.
+func DeleteValue[S ~[]E, E comparable](src S, v E) S {
+	return DeleteFunc(src, func(e E) bool { return e == v })
+}
+
+// Compact 将连续相等的元素替换为一个, 并将空出来的尾部元素置为零值.
+func Compact[S ~[]E, E comparable](src S) S {
+	return CompactFunc(src, func(a, b E) bool { return a == b })
+}
+
+// CompactFunc 使用 eq 判断元素是否相等, 将连续相等的元素替换为第一个,
+// 并将空出来的尾部元素置为零值.
+func CompactFunc[S ~[]E, E any](src S, eq func(a, b E) bool) S {
+	if len(src) < 2 {
+		return src
+	}
+	n := 1
+	for _, v := range src[1:] {
+		if !eq(src[n-1], v) {
+			src[n] = v
+			n++
+		}
+	}
+	zero(src[n:])
+	return src[:n]
+}
+
+// checkRange 检查 [lo, hi) 是否是长度为 length 的切片中合法的范围.
+func checkRange(length, lo, hi int) error {
+	if lo < 0 || lo > length {
+		return errs.NewErrIndexOutOfRange(length, lo)
+	}
+	if hi < lo || hi > length {
+		return errs.NewErrIndexOutOfRange(length, hi)
+	}
+	return nil
+}
+
+// zero 将 s 中的元素置为零值, 避免底层数组继续持有指针导致内存泄漏.
+func zero[S ~[]E, E any](s S) {
+	var zeroValue E
+	for i := range s {
+		s[i] = zeroValue
+	}
 }
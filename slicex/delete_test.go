@@ -1,9 +1,11 @@
 package slicex
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/udugong/ukit/internal/errs"
 )
@@ -41,3 +43,200 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteWithStrategy(t *testing.T) {
+	strategies := []DeleteStrategy{DeleteStrategyAppend, DeleteStrategyCopy}
+	for _, strategy := range strategies {
+		got, err := Delete([]int{1, 2, 3}, 1, WithStrategy(strategy))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 3}, got)
+
+		got, err = Delete([]int{1, 2, 3}, 3, WithStrategy(strategy))
+		assert.Equal(t, errs.NewErrIndexOutOfRange(3, 3), err)
+		assert.Nil(t, got)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name    string
+		src     S
+		lo, hi  int
+		want    S
+		wantErr error
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "middle",
+			src:  []int{1, 2, 3, 4, 5},
+			lo:   1,
+			hi:   3,
+			want: []int{1, 4, 5},
+		},
+		{
+			name: "all",
+			src:  []int{1, 2, 3},
+			lo:   0,
+			hi:   3,
+			want: []int{},
+		},
+		{
+			name: "empty_range",
+			src:  []int{1, 2, 3},
+			lo:   3,
+			hi:   3,
+			want: []int{1, 2, 3},
+		},
+		{
+			name:    "negative_lo",
+			src:     []int{1, 2, 3},
+			lo:      -1,
+			hi:      2,
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+		{
+			name:    "hi_out_of_range",
+			src:     []int{1, 2, 3},
+			lo:      1,
+			hi:      4,
+			wantErr: errs.NewErrIndexOutOfRange(3, 4),
+		},
+		{
+			name:    "hi_less_than_lo",
+			src:     []int{1, 2, 3},
+			lo:      2,
+			hi:      1,
+			wantErr: errs.NewErrIndexOutOfRange(3, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeleteRange(tt.src, tt.lo, tt.hi)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeleteFunc(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3, 4, 5},
+			want: []int{1, 3, 5},
+		},
+		{
+			name: "none",
+			src:  []int{1, 3},
+			want: []int{1, 3},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DeleteFunc(tt.src, func(v int) bool { return v%2 == 0 }))
+		})
+	}
+}
+
+func TestDeleteValue(t *testing.T) {
+	assert.Equal(t, []string{"b", "c"}, DeleteValue([]string{"a", "b", "a", "c"}, "a"))
+	assert.Equal(t, []string{"b"}, DeleteValue([]string{"b"}, "a"))
+}
+
+func TestCompact(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 1, 2, 3, 3, 3, 1},
+			want: []int{1, 2, 3, 1},
+		},
+		{
+			name: "single",
+			src:  []int{1},
+			want: []int{1},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compact(tt.src))
+		})
+	}
+}
+
+func TestCompactFunc(t *testing.T) {
+	got := CompactFunc([]string{"a", "A", "b", "B", "a"}, strings.EqualFold)
+	assert.Equal(t, []string{"a", "b", "a"}, got)
+}
+
+func TestDeleteZeroTail(t *testing.T) {
+	newSrc := func() []*int {
+		src := make([]*int, 5)
+		for i := range src {
+			v := i
+			src[i] = &v
+		}
+		return src
+	}
+	tests := []struct {
+		name   string
+		delete func(src []*int) []*int
+	}{
+		{
+			name: "delete_range",
+			delete: func(src []*int) []*int {
+				res, err := DeleteRange(src, 1, 3)
+				require.NoError(t, err)
+				return res
+			},
+		},
+		{
+			name: "delete_func",
+			delete: func(src []*int) []*int {
+				return DeleteFunc(src, func(v *int) bool { return *v%2 == 1 })
+			},
+		},
+		{
+			name: "compact",
+			delete: func(src []*int) []*int {
+				return CompactFunc(src, func(a, b *int) bool { return *b < 3 })
+			},
+		},
+		{
+			name: "replace",
+			delete: func(src []*int) []*int {
+				res, err := Replace(src, 0, 4, src[4])
+				require.NoError(t, err)
+				return res
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := newSrc()
+			got := tt.delete(src)
+			for _, v := range src[len(got):] {
+				assert.Nil(t, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package slicex
+
+// GroupBy 按照 key 返回的键对 src 中的元素分组, 每组内保持原来的顺序.
+func GroupBy[S ~[]E, E any, K comparable](src S, key func(E) K) map[K]S {
+	groups := make(map[K]S)
+	for _, v := range src {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Partition 将 src 分为 fn 返回 true 和返回 false 的两部分, 并保持原来的顺序.
+func Partition[S ~[]E, E any](src S, fn func(E) bool) (S, S) {
+	matched, others := make(S, 0), make(S, 0)
+	for _, v := range src {
+		if fn(v) {
+			matched = append(matched, v)
+		} else {
+			others = append(others, v)
+		}
+	}
+	return matched, others
+}
+
+// Chunk 将 src 按照 size 分块, 最后一块的长度可能小于 size.
+// 每一块都与 src 共享底层数组, 但是不能通过 append 修改到下一块.
+// size 必须大于0 否则会 panic.
+func Chunk[S ~[]E, E any](src S, size int) []S {
+	if size < 1 {
+		panic("ukit: 分块大小必须为正数")
+	}
+	chunks := make([]S, 0, (len(src)+size-1)/size)
+	for i := 0; i < len(src); i += size {
+		end := i + size
+		if end > len(src) {
+			end = len(src)
+		}
+		chunks = append(chunks, src[i:end:end])
+	}
+	return chunks
+}
+
+// Flatten 将多个切片按顺序拼接为一个新切片.
+func Flatten[S ~[]E, E any](src []S) S {
+	n := 0
+	for _, s := range src {
+		n += len(s)
+	}
+	dst := make(S, 0, n)
+	for _, s := range src {
+		dst = append(dst, s...)
+	}
+	return dst
+}
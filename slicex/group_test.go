@@ -0,0 +1,144 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBy(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want map[bool]S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3, 4, 5},
+			want: map[bool][]int{true: {2, 4}, false: {1, 3, 5}},
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: map[bool][]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GroupBy(tt.src, func(v int) bool { return v%2 == 0 }))
+		})
+	}
+}
+
+func TestPartition(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name        string
+		src         S
+		wantMatched S
+		wantOthers  S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:        "normal",
+			src:         []int{1, 2, 3, 4, 5},
+			wantMatched: []int{2, 4},
+			wantOthers:  []int{1, 3, 5},
+		},
+		{
+			name:        "nil",
+			src:         nil,
+			wantMatched: []int{},
+			wantOthers:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, others := Partition(tt.src, func(v int) bool { return v%2 == 0 })
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.wantOthers, others)
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name      string
+		src       S
+		size      int
+		want      []S
+		wantPanic bool
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3, 4, 5},
+			size: 2,
+			want: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name: "exact",
+			src:  []int{1, 2, 3, 4},
+			size: 2,
+			want: [][]int{{1, 2}, {3, 4}},
+		},
+		{
+			name: "size_larger_than_len",
+			src:  []int{1, 2},
+			size: 5,
+			want: [][]int{{1, 2}},
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			size: 2,
+			want: [][]int{},
+		},
+		{
+			name:      "invalid_size",
+			src:       []int{1},
+			size:      0,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantPanic {
+				assert.Panics(t, func() { Chunk(tt.src, tt.size) })
+				return
+			}
+			assert.Equal(t, tt.want, Chunk(tt.src, tt.size))
+		})
+	}
+
+	// append 不会覆盖下一块
+	src := []int{1, 2, 3, 4}
+	chunks := Chunk(src, 2)
+	_ = append(chunks[0], 100)
+	assert.Equal(t, []int{1, 2, 3, 4}, src)
+}
+
+func TestFlatten(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  []S
+		want S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  [][]int{{1, 2}, {}, {3}, nil, {4, 5}},
+			want: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Flatten(tt.src))
+		})
+	}
+}
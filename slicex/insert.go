@@ -0,0 +1,70 @@
+package slicex
+
+import "unsafe"
+
+// Insert 在 index 处插入 vals, index 的范围为 [0, len(src)].
+// 容量足够时会直接修改 src 的底层数组. vals 可以与 src 的底层数组重叠, 例如 Insert(s, 0, s[2:]...).
+func Insert[S ~[]E, E any](src S, index int, vals ...E) (S, error) {
+	if err := checkRange(len(src), index, index); err != nil {
+		return S(nil), err
+	}
+	return insert(src, index, vals), nil
+}
+
+// Replace 使用 vals 替换 src[lo:hi] 中的元素.
+// 替换后长度变短时会将空出来的尾部元素置为零值.
+// 容量足够时会直接修改 src 的底层数组, vals 可以与 src 的底层数组重叠.
+func Replace[S ~[]E, E any](src S, lo, hi int, vals ...E) (S, error) {
+	if err := checkRange(len(src), lo, hi); err != nil {
+		return S(nil), err
+	}
+	if n := hi - lo; len(vals) > n {
+		// 先复制 vals, 避免写入 src[lo:hi] 时修改了 vals 中还没有用到的元素
+		if overlaps(src[:cap(src)], vals) {
+			vals = append([]E(nil), vals...)
+		}
+		copy(src[lo:hi], vals[:n])
+		return insert(src, hi, vals[n:]), nil
+	}
+	length := len(src)
+	copy(src[lo:], vals)
+	src = append(src[:lo+len(vals)], src[hi:]...)
+	zero(src[len(src):length])
+	return src, nil
+}
+
+func insert[S ~[]E, E any](src S, index int, vals []E) S {
+	n := len(vals)
+	if n == 0 {
+		return src
+	}
+	length := len(src)
+	if length+n > cap(src) {
+		res := make(S, length+n, growCap(cap(src), length+n))
+		copy(res, src[:index])
+		copy(res[index:], vals)
+		copy(res[index+n:], src[index:])
+		return res
+	}
+	// 移动 src 中的元素会修改底层数组, 所以重叠时先复制 vals
+	if overlaps(src[:cap(src)], vals) {
+		vals = append([]E(nil), vals...)
+	}
+	src = src[:length+n]
+	copy(src[index+n:], src[index:length])
+	copy(src[index:], vals)
+	return src
+}
+
+// overlaps 判断 a 和 b 是否共享了底层数组中的某个元素.
+func overlaps[E any](a, b []E) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	size := unsafe.Sizeof(a[0])
+	if size == 0 {
+		return false
+	}
+	return uintptr(unsafe.Pointer(&a[0])) <= uintptr(unsafe.Pointer(&b[len(b)-1]))+(size-1) &&
+		uintptr(unsafe.Pointer(&b[0])) <= uintptr(unsafe.Pointer(&a[len(a)-1]))+(size-1)
+}
@@ -0,0 +1,195 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/udugong/ukit/internal/errs"
+)
+
+func TestInsert(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name    string
+		src     S
+		index   int
+		vals    S
+		want    S
+		wantErr error
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:  "head",
+			src:   []int{1, 2, 3},
+			index: 0,
+			vals:  []int{8, 9},
+			want:  []int{8, 9, 1, 2, 3},
+		},
+		{
+			name:  "middle",
+			src:   []int{1, 2, 3},
+			index: 1,
+			vals:  []int{8, 9},
+			want:  []int{1, 8, 9, 2, 3},
+		},
+		{
+			name:  "tail",
+			src:   []int{1, 2, 3},
+			index: 3,
+			vals:  []int{8},
+			want:  []int{1, 2, 3, 8},
+		},
+		{
+			name:  "enough_capacity",
+			src:   append(make([]int, 0, 10), 1, 2, 3),
+			index: 1,
+			vals:  []int{8, 9},
+			want:  []int{1, 8, 9, 2, 3},
+		},
+		{
+			name:  "no_vals",
+			src:   []int{1, 2},
+			index: 1,
+			want:  []int{1, 2},
+		},
+		{
+			name:    "out_of_range",
+			src:     []int{1, 2, 3},
+			index:   4,
+			vals:    []int{8},
+			wantErr: errs.NewErrIndexOutOfRange(3, 4),
+		},
+		{
+			name:    "negative_index",
+			src:     []int{1, 2, 3},
+			index:   -1,
+			vals:    []int{8},
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Insert(tt.src, tt.index, tt.vals...)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestInsert_Overlap vals 与 src 的底层数组重叠时结果依然正确.
+func TestInsert_Overlap(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		vals  func(s []int) []int
+		want  []int
+	}{
+		{name: "after_index", index: 0, vals: func(s []int) []int { return s[2:4] }, want: []int{3, 4, 1, 2, 3, 4}},
+		{name: "before_index", index: 2, vals: func(s []int) []int { return s[0:2] }, want: []int{1, 2, 1, 2, 3, 4}},
+		{name: "whole", index: 1, vals: func(s []int) []int { return s[:4] }, want: []int{1, 1, 2, 3, 4, 2, 3, 4}},
+		{name: "spare_capacity", index: 0, vals: func(s []int) []int { return s[4:6] }, want: []int{5, 6, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := make([]int, 6, 10)
+			copy(s, []int{1, 2, 3, 4, 5, 6})
+			vals := tt.vals(s)
+			s = s[:4]
+			got, err := Insert(s, tt.index, vals...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name    string
+		src     S
+		lo, hi  int
+		vals    S
+		want    S
+		wantErr error
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "same_length",
+			src:  []int{1, 2, 3, 4},
+			lo:   1,
+			hi:   3,
+			vals: []int{8, 9},
+			want: []int{1, 8, 9, 4},
+		},
+		{
+			name: "shorter",
+			src:  []int{1, 2, 3, 4},
+			lo:   0,
+			hi:   3,
+			vals: []int{8},
+			want: []int{8, 4},
+		},
+		{
+			name: "longer",
+			src:  []int{1, 2, 3, 4},
+			lo:   1,
+			hi:   2,
+			vals: []int{7, 8, 9},
+			want: []int{1, 7, 8, 9, 3, 4},
+		},
+		{
+			name: "empty_range",
+			src:  []int{1, 2},
+			lo:   2,
+			hi:   2,
+			vals: []int{3},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "no_vals",
+			src:  []int{1, 2, 3},
+			lo:   0,
+			hi:   2,
+			want: []int{3},
+		},
+		{
+			name:    "out_of_range",
+			src:     []int{1, 2, 3},
+			lo:      1,
+			hi:      5,
+			vals:    []int{8},
+			wantErr: errs.NewErrIndexOutOfRange(3, 5),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Replace(tt.src, tt.lo, tt.hi, tt.vals...)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestReplace_Overlap vals 与 src 的底层数组重叠时结果依然正确.
+func TestReplace_Overlap(t *testing.T) {
+	s := make([]int, 4, 10)
+	copy(s, []int{1, 2, 3, 4})
+	got, err := Replace(s, 0, 1, s[1:4]...)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 4, 2, 3, 4}, got)
+
+	s = make([]int, 4, 10)
+	copy(s, []int{1, 2, 3, 4})
+	got, err = Replace(s, 2, 3, s[0:3]...)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1, 2, 3, 4}, got)
+}
+
+func TestOverlaps(t *testing.T) {
+	s := make([]int, 10)
+	assert.True(t, overlaps(s[:5], s[4:]))
+	assert.False(t, overlaps(s[:5], s[5:]))
+	assert.False(t, overlaps(s[:0], s))
+	assert.False(t, overlaps(s, make([]int, 3)))
+	empty := make([]struct{}, 3)
+	assert.False(t, overlaps(empty, empty))
+}
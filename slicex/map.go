@@ -0,0 +1,41 @@
+package slicex
+
+// Map 对 src 中的每个元素调用 fn, 返回由结果组成的新切片.
+func Map[S ~[]E, E, R any](src S, fn func(E) R) []R {
+	dst := make([]R, 0, len(src))
+	for _, v := range src {
+		dst = append(dst, fn(v))
+	}
+	return dst
+}
+
+// FilterMap 对 src 中的每个元素调用 fn, 只保留 fn 返回 true 的结果.
+func FilterMap[S ~[]E, E, R any](src S, fn func(E) (R, bool)) []R {
+	dst := make([]R, 0, len(src))
+	for _, v := range src {
+		if r, ok := fn(v); ok {
+			dst = append(dst, r)
+		}
+	}
+	return dst
+}
+
+// Filter 返回由 fn 返回 true 的元素组成的新切片, 并不会修改 src.
+func Filter[S ~[]E, E any](src S, fn func(E) bool) S {
+	dst := make(S, 0, len(src))
+	for _, v := range src {
+		if fn(v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// Reduce 从 init 开始依次使用 fn 累积 src 中的元素.
+func Reduce[S ~[]E, E, R any](src S, init R, fn func(acc R, v E) R) R {
+	acc := init
+	for _, v := range src {
+		acc = fn(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,117 @@
+package slicex
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want []string
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3},
+			want: []string{"1", "2", "3"},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Map(tt.src, strconv.Itoa))
+		})
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want []int
+	}
+	tests := []testCase[[]string, string]{
+		{
+			name: "normal",
+			src:  []string{"1", "a", "3"},
+			want: []int{1, 3},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterMap(tt.src, func(s string) (int, bool) {
+				v, err := strconv.Atoi(s)
+				return v, err == nil
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3, 4, 5},
+			want: []int{2, 4},
+		},
+		{
+			name: "none",
+			src:  []int{1, 3},
+			want: []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := append([]int(nil), tt.src...)
+			assert.Equal(t, tt.want, Filter(tt.src, func(v int) bool { return v%2 == 0 }))
+			assert.Equal(t, src, tt.src)
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want string
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3},
+			want: "0123",
+		},
+		{
+			name: "empty",
+			src:  []int{},
+			want: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reduce(tt.src, "0", func(acc string, v int) string {
+				return acc + strconv.Itoa(v)
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package slicex
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/udugong/ukit/internal/errs"
+)
+
+// ParallelMap 使用最多 limit 个 goroutine 并发地对 src 中的每个元素调用 fn,
+// 返回的结果与 src 的顺序一致. limit 小于等于0时使用 runtime.GOMAXPROCS(0).
+// fn 返回错误或 panic 时会取消传给其它 fn 的 ctx, 不再处理剩下的元素, 并返回第一个错误.
+// panic 会被转换为包含堆栈信息的错误, fn 调用 runtime.Goexit 时同样会返回错误.
+func ParallelMap[S ~[]E, E, R any](ctx context.Context, src S, limit int,
+	fn func(ctx context.Context, v E) (R, error)) ([]R, error) {
+	res := make([]R, len(src))
+	err := parallelDo(ctx, len(src), limit, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, src[i])
+		res[i] = r
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ParallelFilter 使用最多 limit 个 goroutine 并发地对 src 中的每个元素调用 fn,
+// 返回 fn 返回 true 的元素, 结果与 src 的顺序一致.
+// limit, 错误和 panic 的处理方式与 ParallelMap 相同.
+func ParallelFilter[S ~[]E, E any](ctx context.Context, src S, limit int,
+	fn func(ctx context.Context, v E) (bool, error)) (S, error) {
+	keep, err := ParallelMap(ctx, src, limit, fn)
+	if err != nil {
+		return nil, err
+	}
+	res := make(S, 0, len(src))
+	for i, ok := range keep {
+		if ok {
+			res = append(res, src[i])
+		}
+	}
+	return res, nil
+}
+
+// ParallelForEach 使用最多 limit 个 goroutine 并发地对 src 中的每个元素调用 fn.
+// limit, 错误和 panic 的处理方式与 ParallelMap 相同.
+func ParallelForEach[S ~[]E, E any](ctx context.Context, src S, limit int,
+	fn func(ctx context.Context, v E) error) error {
+	return parallelDo(ctx, len(src), limit, func(ctx context.Context, i int) error {
+		return fn(ctx, src[i])
+	})
+}
+
+// parallelDo 使用最多 limit 个 goroutine 对 [0, n) 中的每个下标调用 fn, 返回第一个错误.
+// 如果 ctx 在处理完所有下标之前被取消, 返回 ctx.Err().
+func parallelDo(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > n {
+		limit = n
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		next     atomic.Int64
+	)
+	setErr := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	wg.Add(limit)
+	for w := 0; w < limit; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					setErr(err)
+					return
+				}
+				if err := safeCall(ctx, i, fn, setErr); err != nil {
+					setErr(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// safeCall 调用 fn, 将 panic 转换为错误.
+// runtime.Goexit 无法被阻止, 调用它的 goroutine 会在 safeCall 之后退出,
+// 所以只能在 defer 中通过 setErr 报告 errs.ErrGoexit, 否则剩下的下标会被悄悄跳过.
+func safeCall(ctx context.Context, i int, fn func(ctx context.Context, i int) error,
+	setErr func(err error)) (err error) {
+	normalReturn := false
+	recovered := false
+	// 使用两层 defer 区分 panic 和 runtime.Goexit, 与 singleflight 相同
+	defer func() {
+		if !normalReturn && !recovered {
+			setErr(errs.ErrGoexit)
+		}
+	}()
+	func() {
+		defer func() {
+			if !normalReturn {
+				if r := recover(); r != nil {
+					err = errs.NewPanicError(r)
+				}
+			}
+		}()
+		err = fn(ctx, i)
+		normalReturn = true
+	}()
+	if !normalReturn {
+		recovered = true
+	}
+	return err
+}
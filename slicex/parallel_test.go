@@ -0,0 +1,146 @@
+package slicex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/udugong/ukit/internal/errs"
+)
+
+func TestParallelMap(t *testing.T) {
+	src := make([]int, 1000)
+	for i := range src {
+		src[i] = i
+	}
+	for _, limit := range []int{-1, 0, 1, 4, 2000} {
+		t.Run(strconv.Itoa(limit), func(t *testing.T) {
+			got, err := ParallelMap(context.Background(), src, limit, func(_ context.Context, v int) (string, error) {
+				return strconv.Itoa(v), nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, Map(src, strconv.Itoa), got)
+		})
+	}
+
+	got, err := ParallelMap(context.Background(), []int(nil), 4, func(_ context.Context, v int) (int, error) {
+		return v, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{}, got)
+}
+
+func TestParallelFilter(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got, err := ParallelFilter(context.Background(), src, 3, func(_ context.Context, v int) (bool, error) {
+		return v%2 == 0, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6, 8}, got)
+}
+
+func TestParallelForEach_Limit(t *testing.T) {
+	const limit = 3
+	var running, maxRunning, calls atomic.Int64
+	err := ParallelForEach(context.Background(), make([]int, 50), limit, func(_ context.Context, _ int) error {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := maxRunning.Load()
+			if cur <= old || maxRunning.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), calls.Load())
+	assert.LessOrEqual(t, maxRunning.Load(), int64(limit))
+}
+
+func TestParallelForEach_Error(t *testing.T) {
+	wantErr := errors.New("mock error")
+	var calls atomic.Int64
+	err := ParallelForEach(context.Background(), make([]int, 1000), 4, func(ctx context.Context, _ int) error {
+		if calls.Add(1) == 10 {
+			return wantErr
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Millisecond):
+		}
+		return nil
+	})
+	assert.Equal(t, wantErr, err)
+	// 出现错误后不再处理剩下的元素
+	assert.Less(t, calls.Load(), int64(1000))
+}
+
+func TestParallelForEach_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int64
+	err := ParallelForEach(ctx, make([]int, 10), 2, func(_ context.Context, _ int) error {
+		calls.Add(1)
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, int64(0), calls.Load())
+}
+
+func TestParallelMap_Panic(t *testing.T) {
+	wantErr := errors.New("mock error")
+	tests := []struct {
+		name       string
+		value      any
+		wantUnwrap error
+	}{
+		{name: "string", value: "boom"},
+		{name: "error", value: wantErr, wantUnwrap: wantErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParallelMap(context.Background(), []int{1, 2, 3}, 2, func(_ context.Context, v int) (int, error) {
+				if v == 2 {
+					panic(tt.value)
+				}
+				return v, nil
+			})
+			var pe *errs.PanicError
+			require.ErrorAs(t, err, &pe)
+			assert.Equal(t, tt.value, pe.Value)
+			assert.Contains(t, err.Error(), "parallel_test.go")
+			assert.Equal(t, tt.wantUnwrap, errors.Unwrap(err))
+		})
+	}
+}
+
+func TestParallelForEach_Goexit(t *testing.T) {
+	for _, limit := range []int{1, 2} {
+		t.Run(fmt.Sprintf("limit_%d", limit), func(t *testing.T) {
+			var called atomic.Int64
+			err := ParallelForEach(context.Background(), []int{1, 2, 3, 4}, limit, func(_ context.Context, v int) error {
+				called.Add(1)
+				if v == 2 {
+					runtime.Goexit()
+				}
+				return nil
+			})
+			assert.Equal(t, errs.ErrGoexit, err)
+			if limit == 1 {
+				// 唯一的 goroutine 退出后不会再处理剩下的元素
+				assert.Equal(t, int64(2), called.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package slicex
+
+import "golang.org/x/exp/constraints"
+
+// Contains 判断 src 中是否存在 v.
+func Contains[S ~[]E, E comparable](src S, v E) bool {
+	return IndexOf(src, v) >= 0
+}
+
+// ContainsFunc 判断 src 中是否存在 fn 返回 true 的元素.
+func ContainsFunc[S ~[]E, E any](src S, fn func(E) bool) bool {
+	_, ok := Find(src, fn)
+	return ok
+}
+
+// IndexOf 返回 v 在 src 中第一次出现的下标, 不存在时返回 -1.
+func IndexOf[S ~[]E, E comparable](src S, v E) int {
+	for i, e := range src {
+		if e == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOf 返回 v 在 src 中最后一次出现的下标, 不存在时返回 -1.
+func LastIndexOf[S ~[]E, E comparable](src S, v E) int {
+	for i := len(src) - 1; i >= 0; i-- {
+		if src[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Find 返回第一个 fn 返回 true 的元素, 不存在时返回零值和 false.
+func Find[S ~[]E, E any](src S, fn func(E) bool) (E, bool) {
+	for _, e := range src {
+		if fn(e) {
+			return e, true
+		}
+	}
+	var zeroValue E
+	return zeroValue, false
+}
+
+// FindLast 返回最后一个 fn 返回 true 的元素, 不存在时返回零值和 false.
+func FindLast[S ~[]E, E any](src S, fn func(E) bool) (E, bool) {
+	for i := len(src) - 1; i >= 0; i-- {
+		if fn(src[i]) {
+			return src[i], true
+		}
+	}
+	var zeroValue E
+	return zeroValue, false
+}
+
+// BinarySearchBy 在按照 key 升序排列的 src 中查找 target.
+// 返回第一个键大于等于 target 的下标, 以及该元素的键是否等于 target.
+func BinarySearchBy[S ~[]E, E any, K constraints.Ordered](src S, target K, key func(E) K) (int, bool) {
+	lo, hi := 0, len(src)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if key(src[mid]) < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(src) && key(src[lo]) == target
+}
+
+// MinBy 返回 key 最小的元素, 有多个时返回第一个. src 为空时返回零值和 false.
+func MinBy[S ~[]E, E any, K constraints.Ordered](src S, key func(E) K) (E, bool) {
+	return extremeBy(src, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy 返回 key 最大的元素, 有多个时返回第一个. src 为空时返回零值和 false.
+func MaxBy[S ~[]E, E any, K constraints.Ordered](src S, key func(E) K) (E, bool) {
+	return extremeBy(src, key, func(a, b K) bool { return a > b })
+}
+
+// extremeBy 返回 key 最符合 better 的第一个元素.
+func extremeBy[S ~[]E, E any, K constraints.Ordered](src S, key func(E) K, better func(a, b K) bool) (E, bool) {
+	if len(src) == 0 {
+		var zeroValue E
+		return zeroValue, false
+	}
+	res, resKey := src[0], key(src[0])
+	for _, e := range src[1:] {
+		if k := key(e); better(k, resKey) {
+			res, resKey = e, k
+		}
+	}
+	return res, true
+}
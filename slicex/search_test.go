@@ -0,0 +1,146 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexOf(t *testing.T) {
+	type testCase[S ~[]E, E comparable] struct {
+		name     string
+		src      S
+		v        E
+		want     int
+		wantLast int
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:     "once",
+			src:      []int{1, 2, 3},
+			v:        2,
+			want:     1,
+			wantLast: 1,
+		},
+		{
+			name:     "twice",
+			src:      []int{2, 1, 2, 3},
+			v:        2,
+			want:     0,
+			wantLast: 2,
+		},
+		{
+			name:     "not_exists",
+			src:      []int{1, 3},
+			v:        2,
+			want:     -1,
+			wantLast: -1,
+		},
+		{
+			name:     "nil",
+			src:      nil,
+			v:        2,
+			want:     -1,
+			wantLast: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IndexOf(tt.src, tt.v))
+			assert.Equal(t, tt.wantLast, LastIndexOf(tt.src, tt.v))
+			assert.Equal(t, tt.want >= 0, Contains(tt.src, tt.v))
+			assert.Equal(t, tt.want >= 0, ContainsFunc(tt.src, func(v int) bool { return v == tt.v }))
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name     string
+		src      S
+		want     E
+		wantLast E
+		wantOk   bool
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name:     "found",
+			src:      []user{{1, "a"}, {2, "b"}, {3, "a"}},
+			want:     user{1, "a"},
+			wantLast: user{3, "a"},
+			wantOk:   true,
+		},
+		{
+			name: "not_found",
+			src:  []user{{2, "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := func(u user) bool { return u.name == "a" }
+			got, ok := Find(tt.src, fn)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+			got, ok = FindLast(tt.src, fn)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantLast, got)
+		})
+	}
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	src := []user{{1, "a"}, {3, "b"}, {3, "c"}, {5, "d"}}
+	tests := []struct {
+		name      string
+		target    int
+		want      int
+		wantFound bool
+	}{
+		{name: "first", target: 1, want: 0, wantFound: true},
+		{name: "duplicate", target: 3, want: 1, wantFound: true},
+		{name: "between", target: 4, want: 3},
+		{name: "smallest", target: 0, want: 0},
+		{name: "largest", target: 6, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := BinarySearchBy(src, tt.target, func(u user) int { return u.id })
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestMinMaxBy(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name    string
+		src     S
+		wantMin E
+		wantMax E
+		wantOk  bool
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name:    "normal",
+			src:     []user{{2, "a"}, {1, "b"}, {3, "c"}, {1, "d"}, {3, "e"}},
+			wantMin: user{1, "b"},
+			wantMax: user{3, "c"},
+			wantOk:  true,
+		},
+		{
+			name: "empty",
+			src:  []user{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := func(u user) int { return u.id }
+			got, ok := MinBy(tt.src, key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantMin, got)
+			got, ok = MaxBy(tt.src, key)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantMax, got)
+		})
+	}
+}
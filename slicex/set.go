@@ -0,0 +1,174 @@
+package slicex
+
+import "github.com/udugong/ukit/set"
+
+// mapThreshold 元素个数超过该值时使用 set.MapSet 判断元素是否存在, 否则使用线性查找.
+// 对于较小的切片, 线性查找没有哈希和分配内存的开销, 速度更快.
+// 该值来自 BenchmarkIntersect 和 BenchmarkUnique 的结果, 两者的性能大约在 64 到 128 之间持平.
+const mapThreshold = 64
+
+// lookup 用于判断元素是否存在, 元素较少时使用线性查找.
+type lookup[E comparable] struct {
+	vals []E
+	m    set.MapSet[E]
+}
+
+// newLookup 创建 lookup, size 为预计存储的元素个数, 超过 threshold 时使用 set.MapSet.
+func newLookup[E comparable](size, threshold int) *lookup[E] {
+	if size > threshold {
+		return &lookup[E]{m: set.New[E](size)}
+	}
+	return &lookup[E]{vals: make([]E, 0, size)}
+}
+
+func newLookupOf[S ~[]E, E comparable](src S, threshold int) *lookup[E] {
+	l := newLookup[E](len(src), threshold)
+	for _, v := range src {
+		l.add(v)
+	}
+	return l
+}
+
+// add 添加元素, 返回元素之前是否不存在.
+func (l *lookup[E]) add(v E) bool {
+	if l.contains(v) {
+		return false
+	}
+	if l.m != nil {
+		l.m.Add(v)
+	} else {
+		l.vals = append(l.vals, v)
+	}
+	return true
+}
+
+func (l *lookup[E]) contains(v E) bool {
+	if l.m != nil {
+		return l.m.Exists(v)
+	}
+	for _, val := range l.vals {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersect 返回同时在 a 和 b 中的元素, 结果去重并保持在 a 中的顺序.
+func Intersect[S ~[]E, E comparable](a, b S) S {
+	return intersect(a, b, mapThreshold)
+}
+
+func intersect[S ~[]E, E comparable](a, b S, threshold int) S {
+	other := newLookupOf(b, threshold)
+	seen := newLookup[E](len(a), threshold)
+	res := make(S, 0, len(a))
+	for _, v := range a {
+		if other.contains(v) && seen.add(v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Union 返回 a 和 b 的并集, 结果去重.
+// 先按顺序保留 a 中的元素, 再按顺序保留只在 b 中的元素.
+func Union[S ~[]E, E comparable](a, b S) S {
+	return union(a, b, mapThreshold)
+}
+
+func union[S ~[]E, E comparable](a, b S, threshold int) S {
+	seen := newLookup[E](len(a)+len(b), threshold)
+	res := make(S, 0, len(a)+len(b))
+	for _, src := range [2]S{a, b} {
+		for _, v := range src {
+			if seen.add(v) {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
+
+// Diff 返回在 a 中但不在 b 中的元素, 结果去重并保持在 a 中的顺序.
+func Diff[S ~[]E, E comparable](a, b S) S {
+	return difference(a, b, mapThreshold)
+}
+
+func difference[S ~[]E, E comparable](a, b S, threshold int) S {
+	return diff(a, newLookupOf(b, threshold), make(S, 0, len(a)), threshold)
+}
+
+// SymmetricDiff 返回只在 a 或只在 b 中的元素, 结果去重.
+// 先按顺序保留只在 a 中的元素, 再按顺序保留只在 b 中的元素.
+func SymmetricDiff[S ~[]E, E comparable](a, b S) S {
+	return symmetricDiff(a, b, mapThreshold)
+}
+
+func symmetricDiff[S ~[]E, E comparable](a, b S, threshold int) S {
+	res := make(S, 0, len(a)+len(b))
+	res = diff(a, newLookupOf(b, threshold), res, threshold)
+	return diff(b, newLookupOf(a, threshold), res, threshold)
+}
+
+// diff 将在 src 中但不在 other 中的元素去重后追加到 dst.
+func diff[S ~[]E, E comparable](src S, other *lookup[E], dst S, threshold int) S {
+	seen := newLookup[E](len(src), threshold)
+	for _, v := range src {
+		if !other.contains(v) && seen.add(v) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// Unique 返回去重后的元素, 保留每个元素第一次出现的位置.
+func Unique[S ~[]E, E comparable](src S) S {
+	return UniqueBy(src, func(v E) E { return v })
+}
+
+// UniqueBy 按照 key 返回的键去重, 保留每个键第一次出现的元素.
+func UniqueBy[S ~[]E, E any, K comparable](src S, key func(E) K) S {
+	return uniqueBy(src, key, mapThreshold)
+}
+
+func uniqueBy[S ~[]E, E any, K comparable](src S, key func(E) K, threshold int) S {
+	seen := newLookup[K](len(src), threshold)
+	res := make(S, 0, len(src))
+	for _, v := range src {
+		if seen.add(key(v)) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// ContainsAll 判断 elems 中的元素是否都在 src 中, elems 为空时返回 true.
+func ContainsAll[S ~[]E, E comparable](src, elems S) bool {
+	return containsAll(src, elems, mapThreshold)
+}
+
+func containsAll[S ~[]E, E comparable](src, elems S, threshold int) bool {
+	l := newLookupOf(src, threshold)
+	for _, v := range elems {
+		if !l.contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny 判断 elems 中是否有任意一个元素在 src 中, elems 为空时返回 false.
+func ContainsAny[S ~[]E, E comparable](src, elems S) bool {
+	return containsAny(src, elems, mapThreshold)
+}
+
+func containsAny[S ~[]E, E comparable](src, elems S, threshold int) bool {
+	l := newLookupOf(src, threshold)
+	for _, v := range elems {
+		if l.contains(v) {
+			return true
+		}
+	}
+	return false
+}
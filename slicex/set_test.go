@@ -0,0 +1,248 @@
+package slicex
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runWithThresholds 分别使用线性查找和 set.MapSet 两种实现运行 fn.
+func runWithThresholds(t *testing.T, fn func(t *testing.T, threshold int)) {
+	for _, threshold := range []int{math.MaxInt, 0} {
+		threshold := threshold
+		t.Run(fmt.Sprintf("threshold_%d", threshold), func(t *testing.T) {
+			fn(t, threshold)
+		})
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	type testCase[S ~[]E, E comparable] struct {
+		name              string
+		a                 S
+		b                 S
+		wantIntersect     S
+		wantUnion         S
+		wantDiff          S
+		wantSymmetricDiff S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:              "normal",
+			a:                 []int{5, 1, 3, 1, 2},
+			b:                 []int{4, 3, 5, 4, 6},
+			wantIntersect:     []int{5, 3},
+			wantUnion:         []int{5, 1, 3, 2, 4, 6},
+			wantDiff:          []int{1, 2},
+			wantSymmetricDiff: []int{1, 2, 4, 6},
+		},
+		{
+			name:              "same",
+			a:                 []int{1, 2},
+			b:                 []int{2, 1},
+			wantIntersect:     []int{1, 2},
+			wantUnion:         []int{1, 2},
+			wantDiff:          []int{},
+			wantSymmetricDiff: []int{},
+		},
+		{
+			name:              "empty_b",
+			a:                 []int{1, 1},
+			b:                 nil,
+			wantIntersect:     []int{},
+			wantUnion:         []int{1},
+			wantDiff:          []int{1},
+			wantSymmetricDiff: []int{1},
+		},
+		{
+			name:              "both_nil",
+			wantIntersect:     []int{},
+			wantUnion:         []int{},
+			wantDiff:          []int{},
+			wantSymmetricDiff: []int{},
+		},
+	}
+	runWithThresholds(t, func(t *testing.T, threshold int) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.wantIntersect, intersect(tt.a, tt.b, threshold))
+				assert.Equal(t, tt.wantUnion, union(tt.a, tt.b, threshold))
+				assert.Equal(t, tt.wantDiff, difference(tt.a, tt.b, threshold))
+				assert.Equal(t, tt.wantSymmetricDiff, symmetricDiff(tt.a, tt.b, threshold))
+			})
+		}
+	})
+}
+
+func TestUnique(t *testing.T) {
+	type testCase[S ~[]E, E comparable] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]string, string]{
+		{
+			name: "normal",
+			src:  []string{"b", "a", "b", "c", "a"},
+			want: []string{"b", "a", "c"},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: []string{},
+		},
+	}
+	runWithThresholds(t, func(t *testing.T, threshold int) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.want, uniqueBy(tt.src, func(v string) string { return v }, threshold))
+			})
+		}
+	})
+}
+
+func TestUniqueBy(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name: "normal",
+			src:  []user{{1, "a"}, {2, "b"}, {1, "c"}},
+			want: []user{{1, "a"}, {2, "b"}},
+		},
+		{
+			name: "empty",
+			src:  []user{},
+			want: []user{},
+		},
+	}
+	runWithThresholds(t, func(t *testing.T, threshold int) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.want, uniqueBy(tt.src, func(u user) int { return u.id }, threshold))
+			})
+		}
+	})
+}
+
+func TestContains(t *testing.T) {
+	type testCase[S ~[]E, E comparable] struct {
+		name    string
+		src     S
+		elems   S
+		wantAll bool
+		wantAny bool
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:    "all",
+			src:     []int{1, 2, 3},
+			elems:   []int{3, 1},
+			wantAll: true,
+			wantAny: true,
+		},
+		{
+			name:    "some",
+			src:     []int{1, 2, 3},
+			elems:   []int{3, 4},
+			wantAll: false,
+			wantAny: true,
+		},
+		{
+			name:    "none",
+			src:     []int{1, 2, 3},
+			elems:   []int{4},
+			wantAll: false,
+			wantAny: false,
+		},
+		{
+			name:    "empty_elems",
+			src:     []int{1},
+			elems:   nil,
+			wantAll: true,
+			wantAny: false,
+		},
+	}
+	runWithThresholds(t, func(t *testing.T, threshold int) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.wantAll, containsAll(tt.src, tt.elems, threshold))
+				assert.Equal(t, tt.wantAny, containsAny(tt.src, tt.elems, threshold))
+			})
+		}
+	})
+}
+
+// BenchmarkIntersect 对比线性查找和 set.MapSet 在不同长度下的性能, 用于确定 mapThreshold.
+// 两者都在长度为 64 到 128 之间持平, 之后线性查找的耗时按平方增长.
+//
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/slicex
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkIntersect/linear_4       169.8 ns/op
+// BenchmarkIntersect/map_4          467.8 ns/op
+// BenchmarkIntersect/linear_8       253.1 ns/op
+// BenchmarkIntersect/map_8          655.0 ns/op
+// BenchmarkIntersect/linear_16      644.9 ns/op
+// BenchmarkIntersect/map_16         1402 ns/op
+// BenchmarkIntersect/linear_32      1159 ns/op
+// BenchmarkIntersect/map_32         2355 ns/op
+// BenchmarkIntersect/linear_64      2954 ns/op
+// BenchmarkIntersect/map_64         4310 ns/op
+// BenchmarkIntersect/linear_128     10373 ns/op
+// BenchmarkIntersect/map_128        7378 ns/op
+// BenchmarkIntersect/linear_256     60817 ns/op
+// BenchmarkIntersect/map_256        20032 ns/op
+// BenchmarkUnique/linear_4          103.1 ns/op
+// BenchmarkUnique/map_4             238.0 ns/op
+// BenchmarkUnique/linear_8          142.2 ns/op
+// BenchmarkUnique/map_8             428.1 ns/op
+// BenchmarkUnique/linear_16         277.2 ns/op
+// BenchmarkUnique/map_16            880.4 ns/op
+// BenchmarkUnique/linear_32         685.5 ns/op
+// BenchmarkUnique/map_32            1704 ns/op
+// BenchmarkUnique/linear_64         1683 ns/op
+// BenchmarkUnique/map_64            3046 ns/op
+// BenchmarkUnique/linear_128        5509 ns/op
+// BenchmarkUnique/map_128           4850 ns/op
+// BenchmarkUnique/linear_256        17823 ns/op
+// BenchmarkUnique/map_256           10570 ns/op
+func BenchmarkIntersect(b *testing.B) {
+	benchmarkThreshold(b, func(x, y []int, threshold int) { intersect(x, y, threshold) })
+}
+
+func BenchmarkUnique(b *testing.B) {
+	benchmarkThreshold(b, func(x, _ []int, threshold int) {
+		uniqueBy(x, func(v int) int { return v }, threshold)
+	})
+}
+
+func benchmarkThreshold(b *testing.B, fn func(x, y []int, threshold int)) {
+	r := rand.New(rand.NewSource(1))
+	for _, size := range []int{4, 8, 16, 32, 64, 128, 256} {
+		x, y := make([]int, size), make([]int, size)
+		for i := range x {
+			x[i], y[i] = r.Intn(size*2), r.Intn(size*2)
+		}
+		for _, s := range []struct {
+			name      string
+			threshold int
+		}{
+			{name: "linear", threshold: math.MaxInt},
+			{name: "map", threshold: 0},
+		} {
+			b.Run(fmt.Sprintf("%s_%d", s.name, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					fn(x, y, s.threshold)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package slicex
+
+// minShrinkCap 容量小于等于该值的切片不会缩容, 重新分配小切片得不偿失.
+const minShrinkCap = 64
+
+// ShrinkIfSparse 当 len(src) < cap(src) * fraction 时重新分配底层数组并复制元素,
+// 否则直接返回 src. fraction 的取值范围一般为 (0, 1], 小于等于0时不会缩容.
+// 缩容后的容量与 runtime 在 len(src) 的基础上扩容一次的容量相同, 为后续追加元素预留空间.
+// 为了避免反复删除时频繁地重新分配, 只有新容量不超过原容量的一半时才会缩容.
+// 容量小于等于 64 的切片不会缩容.
+func ShrinkIfSparse[S ~[]E, E any](src S, fraction float64) S {
+	length, capacity := len(src), cap(src)
+	if capacity <= minShrinkCap || float64(length) >= float64(capacity)*fraction {
+		return src
+	}
+	newCap := shrinkCap(length)
+	if newCap > capacity/2 {
+		return src
+	}
+	res := make(S, length, newCap)
+	copy(res, src)
+	return res
+}
+
+// shrinkCap 返回长度为 length 的切片缩容后的容量.
+func shrinkCap(length int) int {
+	if length == 0 {
+		return 0
+	}
+	return growCap(length, length+1)
+}
+
+// growCap 返回容量为 oldCap 的切片至少需要 needed 的容量时扩容后的容量,
+// 与 runtime.growslice 的策略相同, 但不会按照内存规格向上取整:
+// 容量小于 256 时翻倍, 之后平滑地过渡到每次增长 1.25 倍.
+func growCap(oldCap, needed int) int {
+	newCap := oldCap
+	doubleCap := newCap + newCap
+	if needed > doubleCap {
+		return needed
+	}
+	const threshold = 256
+	if oldCap < threshold {
+		return doubleCap
+	}
+	for newCap < needed {
+		newCap += (newCap + 3*threshold) >> 2
+	}
+	return newCap
+}
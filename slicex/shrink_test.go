@@ -0,0 +1,147 @@
+package slicex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShrinkIfSparse(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   int
+		capacity int
+		fraction float64
+		wantCap  int
+	}{
+		{
+			name:     "small_capacity",
+			length:   1,
+			capacity: 64,
+			fraction: 0.25,
+			wantCap:  64,
+		},
+		{
+			name:     "not_sparse",
+			length:   32,
+			capacity: 128,
+			fraction: 0.25,
+			wantCap:  128,
+		},
+		{
+			name:     "sparse",
+			length:   31,
+			capacity: 128,
+			fraction: 0.25,
+			wantCap:  62,
+		},
+		{
+			name:     "not_halved",
+			length:   40,
+			capacity: 128,
+			fraction: 0.5,
+			wantCap:  128,
+		},
+		{
+			name:     "empty",
+			length:   0,
+			capacity: 128,
+			fraction: 0.25,
+			wantCap:  0,
+		},
+		{
+			name:     "large",
+			length:   1000,
+			capacity: 10000,
+			fraction: 0.25,
+			// 1000 + (1000 + 768) / 4
+			wantCap: 1442,
+		},
+		{
+			name:     "new_capacity_too_large",
+			length:   100,
+			capacity: 300,
+			fraction: 1,
+			wantCap:  300,
+		},
+		{
+			name:     "disabled",
+			length:   1,
+			capacity: 1024,
+			fraction: 0,
+			wantCap:  1024,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := make([]int, tt.length, tt.capacity)
+			for i := range src {
+				src[i] = i
+			}
+			got := ShrinkIfSparse(src, tt.fraction)
+			assert.Equal(t, tt.wantCap, cap(got))
+			assert.Equal(t, src, got)
+		})
+	}
+}
+
+func TestGrowCap(t *testing.T) {
+	tests := []struct {
+		oldCap int
+		needed int
+		want   int
+	}{
+		{oldCap: 0, needed: 5, want: 5},
+		{oldCap: 4, needed: 5, want: 8},
+		{oldCap: 4, needed: 9, want: 9},
+		{oldCap: 255, needed: 256, want: 510},
+		{oldCap: 256, needed: 257, want: 512},
+		{oldCap: 1024, needed: 1025, want: 1472},
+		{oldCap: 1024, needed: 1500, want: 2032},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%d", tt.oldCap, tt.needed), func(t *testing.T) {
+			assert.Equal(t, tt.want, growCap(tt.oldCap, tt.needed))
+		})
+	}
+}
+
+func TestDeleteWithShrink(t *testing.T) {
+	src := make([]int, 1024)
+	var err error
+	for len(src) > 10 {
+		src, err = Delete(src, len(src)-1, WithShrink(0.25))
+		require.NoError(t, err)
+		if cap(src) > minShrinkCap {
+			assert.GreaterOrEqual(t, float64(len(src)), float64(cap(src))*0.25)
+		}
+	}
+	assert.LessOrEqual(t, cap(src), minShrinkCap)
+
+	src, err = Delete(make([]int, 10, 1024), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1024, cap(src))
+}
+
+func BenchmarkDeleteWithShrink(b *testing.B) {
+	const size = 4096
+	for _, bm := range []struct {
+		name string
+		opts []DeleteOption
+	}{
+		{name: "no_shrink"},
+		{name: "shrink_0.25", opts: []DeleteOption{WithShrink(0.25)}},
+		{name: "shrink_0.5", opts: []DeleteOption{WithShrink(0.5)}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				src := make([]int, size)
+				for len(src) > 0 {
+					src, _ = Delete(src, len(src)/2, bm.opts...)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package slicex
+
+import (
+	"math/rand"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SortBy 按照 key 对 src 进行升序的稳定排序, 每个元素只会调用一次 key.
+func SortBy[S ~[]E, E any, K constraints.Ordered](src S, key func(E) K) {
+	sort.Stable(&keyedSlice[E, K]{src: src, keys: Map(src, key)})
+}
+
+// IsSortedBy 判断 src 是否按照 key 升序排列.
+func IsSortedBy[S ~[]E, E any, K constraints.Ordered](src S, key func(E) K) bool {
+	if len(src) < 2 {
+		return true
+	}
+	prev := key(src[0])
+	for _, e := range src[1:] {
+		k := key(e)
+		if k < prev {
+			return false
+		}
+		prev = k
+	}
+	return true
+}
+
+// Shuffle 随机打乱 src 中元素的顺序.
+// source 为 nil 时使用 math/rand 的全局随机数生成器.
+func Shuffle[S ~[]E, E any](src S, source rand.Source) {
+	swap := func(i, j int) {
+		src[i], src[j] = src[j], src[i]
+	}
+	if source == nil {
+		rand.Shuffle(len(src), swap)
+		return
+	}
+	rand.New(source).Shuffle(len(src), swap)
+}
+
+// keyedSlice 同时交换元素和预先计算好的键.
+type keyedSlice[E any, K constraints.Ordered] struct {
+	src  []E
+	keys []K
+}
+
+func (s *keyedSlice[E, K]) Len() int {
+	return len(s.src)
+}
+
+func (s *keyedSlice[E, K]) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
+}
+
+func (s *keyedSlice[E, K]) Swap(i, j int) {
+	s.src[i], s.src[j] = s.src[j], s.src[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
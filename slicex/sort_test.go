@@ -0,0 +1,75 @@
+package slicex
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortBy(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name: "stable",
+			src:  []user{{3, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {3, "e"}},
+			want: []user{{1, "b"}, {1, "d"}, {2, "c"}, {3, "a"}, {3, "e"}},
+		},
+		{
+			name: "empty",
+			src:  []user{},
+			want: []user{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			key := func(u user) int {
+				calls++
+				return u.id
+			}
+			SortBy(tt.src, key)
+			assert.Equal(t, tt.want, tt.src)
+			assert.Equal(t, len(tt.src), calls)
+			assert.True(t, IsSortedBy(tt.src, key))
+		})
+	}
+}
+
+func TestIsSortedBy(t *testing.T) {
+	tests := []struct {
+		name string
+		src  []string
+		want bool
+	}{
+		{name: "sorted", src: []string{"a", "bb", "cc", "ddd"}, want: true},
+		{name: "unsorted", src: []string{"aa", "b"}, want: false},
+		{name: "single", src: []string{"a"}, want: true},
+		{name: "nil", src: nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsSortedBy(tt.src, func(s string) int { return len(s) }))
+		})
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	src := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	a := append([]int(nil), src...)
+	b := append([]int(nil), src...)
+	Shuffle(a, rand.NewSource(1))
+	Shuffle(b, rand.NewSource(1))
+	// 相同的随机源得到相同的结果
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, src, a)
+	assert.ElementsMatch(t, src, a)
+
+	c := append([]int(nil), src...)
+	Shuffle(c, nil)
+	assert.ElementsMatch(t, src, c)
+}
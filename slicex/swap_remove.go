@@ -0,0 +1,31 @@
+package slicex
+
+import "github.com/udugong/ukit/internal/errs"
+
+// SwapRemove 使用最后一个元素替换 index 处的元素, 时间复杂度为 O(1).
+// 不会保持元素的顺序, 原来的最后一个位置会被置为零值.
+func SwapRemove[S ~[]E, E any](src S, index int) (S, error) {
+	length := len(src)
+	if index < 0 || index >= length {
+		return S(nil), errs.NewErrIndexOutOfRange(length, index)
+	}
+	src[index] = src[length-1]
+	zero(src[length-1:])
+	return src[:length-1], nil
+}
+
+// SwapRemoveFunc 删除所有 fn 返回 true 的元素, 每个被删除的位置使用末尾的元素填充.
+// 不会保持元素的顺序, 空出来的尾部元素会被置为零值.
+func SwapRemoveFunc[S ~[]E, E any](src S, fn func(E) bool) S {
+	n := len(src)
+	for i := 0; i < n; {
+		if fn(src[i]) {
+			n--
+			src[i] = src[n]
+		} else {
+			i++
+		}
+	}
+	zero(src[n:])
+	return src[:n]
+}
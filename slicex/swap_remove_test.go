@@ -0,0 +1,111 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/udugong/ukit/internal/errs"
+)
+
+func TestSwapRemove(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name    string
+		src     S
+		index   int
+		want    S
+		wantErr error
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name:  "head",
+			src:   []int{1, 2, 3, 4},
+			index: 0,
+			want:  []int{4, 2, 3},
+		},
+		{
+			name:  "middle",
+			src:   []int{1, 2, 3, 4},
+			index: 1,
+			want:  []int{1, 4, 3},
+		},
+		{
+			name:  "tail",
+			src:   []int{1, 2, 3, 4},
+			index: 3,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "single",
+			src:   []int{1},
+			index: 0,
+			want:  []int{},
+		},
+		{
+			name:    "out_of_range",
+			src:     []int{1, 2, 3},
+			index:   3,
+			wantErr: errs.NewErrIndexOutOfRange(3, 3),
+		},
+		{
+			name:    "negative_index",
+			src:     []int{1, 2, 3},
+			index:   -1,
+			wantErr: errs.NewErrIndexOutOfRange(3, -1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SwapRemove(tt.src, tt.index)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	a, b := 1, 2
+	src := []*int{&a, &b}
+	_, err := SwapRemove(src, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, &b, src[0])
+	assert.Nil(t, src[1])
+}
+
+func TestSwapRemoveFunc(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want S
+	}
+	tests := []testCase[[]int, int]{
+		{
+			name: "normal",
+			src:  []int{1, 2, 3, 4, 5, 6},
+			want: []int{1, 5, 3},
+		},
+		{
+			name: "tail_matches",
+			src:  []int{2, 1, 4, 6},
+			want: []int{1},
+		},
+		{
+			name: "all",
+			src:  []int{2, 4},
+			want: []int{},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := tt.src
+			got := SwapRemoveFunc(src, func(v int) bool { return v%2 == 0 })
+			assert.Equal(t, tt.want, got)
+			for _, v := range src[len(got):] {
+				assert.Zero(t, v)
+			}
+		})
+	}
+}
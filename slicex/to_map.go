@@ -0,0 +1,34 @@
+package slicex
+
+// Associate 使用 fn 返回的键值对构造 map.
+// 如果有重复的键则保留最后一个值.
+func Associate[S ~[]E, E any, K comparable, V any](src S, fn func(E) (K, V)) map[K]V {
+	m := make(map[K]V, len(src))
+	for _, v := range src {
+		k, val := fn(v)
+		m[k] = val
+	}
+	return m
+}
+
+// ToMap 以 key 返回的值作为键, 元素本身作为值构造 map.
+// 如果有重复的键则保留最后一个元素.
+func ToMap[S ~[]E, E any, K comparable](src S, key func(E) K) map[K]E {
+	m := make(map[K]E, len(src))
+	for _, v := range src {
+		m[key(v)] = v
+	}
+	return m
+}
+
+// Index 返回每个元素到它第一次出现的下标的映射.
+// 适用于需要多次查询元素位置的场景.
+func Index[S ~[]E, E comparable](src S) map[E]int {
+	m := make(map[E]int, len(src))
+	for i, v := range src {
+		if _, ok := m[v]; !ok {
+			m[v] = i
+		}
+	}
+	return m
+}
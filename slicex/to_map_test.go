@@ -0,0 +1,88 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	id   int
+	name string
+}
+
+func TestAssociate(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want map[int]string
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name: "normal",
+			src:  []user{{1, "a"}, {2, "b"}},
+			want: map[int]string{1: "a", 2: "b"},
+		},
+		{
+			name: "duplicate_key",
+			src:  []user{{1, "a"}, {1, "b"}},
+			want: map[int]string{1: "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Associate(tt.src, func(u user) (int, string) { return u.id, u.name })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	type testCase[S ~[]E, E any] struct {
+		name string
+		src  S
+		want map[int]E
+	}
+	tests := []testCase[[]user, user]{
+		{
+			name: "normal",
+			src:  []user{{1, "a"}, {2, "b"}},
+			want: map[int]user{1: {1, "a"}, 2: {2, "b"}},
+		},
+		{
+			name: "nil",
+			src:  nil,
+			want: map[int]user{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToMap(tt.src, func(u user) int { return u.id }))
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	type testCase[S ~[]E, E comparable] struct {
+		name string
+		src  S
+		want map[E]int
+	}
+	tests := []testCase[[]string, string]{
+		{
+			name: "normal",
+			src:  []string{"a", "b", "a", "c"},
+			want: map[string]int{"a": 0, "b": 1, "c": 3},
+		},
+		{
+			name: "empty",
+			src:  []string{},
+			want: map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Index(tt.src))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package slicex
+
+// Pair 由两个元素组成的元组.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip 将 a 和 b 中相同下标的元素组合为 Pair.
+// 结果的长度为 a 和 b 中较短的长度.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	dst := make([]Pair[A, B], 0, n)
+	for i := 0; i < n; i++ {
+		dst = append(dst, Pair[A, B]{First: a[i], Second: b[i]})
+	}
+	return dst
+}
+
+// Unzip 是 Zip 的逆操作, 将 Pair 拆分为两个切片.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a, b := make([]A, 0, len(pairs)), make([]B, 0, len(pairs))
+	for _, p := range pairs {
+		a = append(a, p.First)
+		b = append(b, p.Second)
+	}
+	return a, b
+}
@@ -0,0 +1,50 @@
+package slicex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []string
+		want []Pair[int, string]
+	}{
+		{
+			name: "same_length",
+			a:    []int{1, 2},
+			b:    []string{"a", "b"},
+			want: []Pair[int, string]{{1, "a"}, {2, "b"}},
+		},
+		{
+			name: "different_length",
+			a:    []int{1, 2, 3},
+			b:    []string{"a"},
+			want: []Pair[int, string]{{1, "a"}},
+		},
+		{
+			name: "nil",
+			a:    nil,
+			b:    []string{"a"},
+			want: []Pair[int, string]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Zip(tt.a, tt.b))
+		})
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := Unzip([]Pair[int, string]{{1, "a"}, {2, "b"}})
+	assert.Equal(t, []int{1, 2}, a)
+	assert.Equal(t, []string{"a", "b"}, b)
+
+	a, b = Unzip[int, string](nil)
+	assert.Equal(t, []int{}, a)
+	assert.Equal(t, []string{}, b)
+}
@@ -0,0 +1,95 @@
+package stringx
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ToSnakeCase 转换为蛇形命名, 例如 HTTPServer 转换为 http_server.
+func ToSnakeCase(s string) string {
+	return joinWords(s, '_', unicode.ToLower, unicode.ToLower)
+}
+
+// ToScreamingSnake 转换为大写的蛇形命名, 例如 HTTPServer 转换为 HTTP_SERVER.
+func ToScreamingSnake(s string) string {
+	return joinWords(s, '_', unicode.ToUpper, unicode.ToUpper)
+}
+
+// ToKebabCase 转换为短横线命名, 例如 HTTPServer 转换为 http-server.
+func ToKebabCase(s string) string {
+	return joinWords(s, '-', unicode.ToLower, unicode.ToLower)
+}
+
+// ToPascalCase 转换为大驼峰命名, 例如 http_server 转换为 HttpServer.
+// 缩写词只保留首字母大写.
+func ToPascalCase(s string) string {
+	return joinWords(s, 0, unicode.ToTitle, unicode.ToLower)
+}
+
+// ToCamelCase 转换为小驼峰命名, 例如 http_server 转换为 httpServer.
+// 缩写词只保留首字母大写.
+func ToCamelCase(s string) string {
+	res := ToPascalCase(s)
+	r, size := utf8.DecodeRuneInString(res)
+	if size == 0 {
+		return res
+	}
+	return string(unicode.ToLower(r)) + res[size:]
+}
+
+// joinWords 将 s 拆分为单词, 每个单词的首字母使用 first 转换, 其余字母使用 rest 转换,
+// 然后使用 sep 连接. sep 为0时直接连接.
+func joinWords(s string, sep rune, first, rest func(rune) rune) string {
+	var sb strings.Builder
+	sb.Grow(len(s) + len(s)/4)
+	for i, word := range splitWords(s) {
+		if i > 0 && sep != 0 {
+			sb.WriteRune(sep)
+		}
+		for j, r := range word {
+			if j == 0 {
+				sb.WriteRune(first(r))
+			} else {
+				sb.WriteRune(rest(r))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// splitWords 将 s 拆分为单词.
+// 字母和数字以外的字符都是分隔符, 另外在以下位置拆分:
+//   - 非大写字母或数字之后的大写字母, 例如 fooBar 拆分为 foo Bar, Base64Encode 拆分为 Base64 Encode.
+//   - 连续大写字母中后面紧跟小写字母的最后一个大写字母, 例如 HTTPServer 拆分为 HTTP Server.
+//
+// 数字总是属于前一个单词, 例如 Int64 不会被拆分.
+func splitWords(s string) [][]rune {
+	runes := []rune(s)
+	words := make([][]rune, 0, 4)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, runes[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if !unicode.IsUpper(prev) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				words = append(words, runes[start:i])
+				start = -1
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, runes[start:])
+	}
+	return words
+}
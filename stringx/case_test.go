@@ -0,0 +1,200 @@
+package stringx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		wantSnake     string
+		wantScreaming string
+		wantKebab     string
+		wantPascal    string
+		wantCamel     string
+	}{
+		{
+			name:          "empty",
+			s:             "",
+			wantSnake:     "",
+			wantScreaming: "",
+			wantKebab:     "",
+			wantPascal:    "",
+			wantCamel:     "",
+		},
+		{
+			name:          "single_word",
+			s:             "user",
+			wantSnake:     "user",
+			wantScreaming: "USER",
+			wantKebab:     "user",
+			wantPascal:    "User",
+			wantCamel:     "user",
+		},
+		{
+			name:          "pascal",
+			s:             "UserName",
+			wantSnake:     "user_name",
+			wantScreaming: "USER_NAME",
+			wantKebab:     "user-name",
+			wantPascal:    "UserName",
+			wantCamel:     "userName",
+		},
+		{
+			name:          "camel",
+			s:             "userName",
+			wantSnake:     "user_name",
+			wantScreaming: "USER_NAME",
+			wantKebab:     "user-name",
+			wantPascal:    "UserName",
+			wantCamel:     "userName",
+		},
+		{
+			name:          "snake",
+			s:             "user_name",
+			wantSnake:     "user_name",
+			wantScreaming: "USER_NAME",
+			wantKebab:     "user-name",
+			wantPascal:    "UserName",
+			wantCamel:     "userName",
+		},
+		{
+			name:          "screaming_snake",
+			s:             "USER_NAME",
+			wantSnake:     "user_name",
+			wantScreaming: "USER_NAME",
+			wantKebab:     "user-name",
+			wantPascal:    "UserName",
+			wantCamel:     "userName",
+		},
+		{
+			name:          "kebab",
+			s:             "user-name",
+			wantSnake:     "user_name",
+			wantScreaming: "USER_NAME",
+			wantKebab:     "user-name",
+			wantPascal:    "UserName",
+			wantCamel:     "userName",
+		},
+		{
+			name:          "leading_acronym",
+			s:             "HTTPServer",
+			wantSnake:     "http_server",
+			wantScreaming: "HTTP_SERVER",
+			wantKebab:     "http-server",
+			wantPascal:    "HttpServer",
+			wantCamel:     "httpServer",
+		},
+		{
+			name:          "trailing_acronym",
+			s:             "UserID",
+			wantSnake:     "user_id",
+			wantScreaming: "USER_ID",
+			wantKebab:     "user-id",
+			wantPascal:    "UserId",
+			wantCamel:     "userId",
+		},
+		{
+			name:          "middle_acronym",
+			s:             "parseJSONData",
+			wantSnake:     "parse_json_data",
+			wantScreaming: "PARSE_JSON_DATA",
+			wantKebab:     "parse-json-data",
+			wantPascal:    "ParseJsonData",
+			wantCamel:     "parseJsonData",
+		},
+		{
+			name:          "digits",
+			s:             "Base64Encode",
+			wantSnake:     "base64_encode",
+			wantScreaming: "BASE64_ENCODE",
+			wantKebab:     "base64-encode",
+			wantPascal:    "Base64Encode",
+			wantCamel:     "base64Encode",
+		},
+		{
+			name:          "acronym_digits",
+			s:             "HTTP2Server",
+			wantSnake:     "http2_server",
+			wantScreaming: "HTTP2_SERVER",
+			wantKebab:     "http2-server",
+			wantPascal:    "Http2Server",
+			wantCamel:     "http2Server",
+		},
+		{
+			name:          "digits_lower",
+			s:             "int64Value",
+			wantSnake:     "int64_value",
+			wantScreaming: "INT64_VALUE",
+			wantKebab:     "int64-value",
+			wantPascal:    "Int64Value",
+			wantCamel:     "int64Value",
+		},
+		{
+			name:          "leading_digits",
+			s:             "2fa_code",
+			wantSnake:     "2fa_code",
+			wantScreaming: "2FA_CODE",
+			wantKebab:     "2fa-code",
+			wantPascal:    "2faCode",
+			wantCamel:     "2faCode",
+		},
+		{
+			name:          "separators",
+			s:             "  __user..name--id  ",
+			wantSnake:     "user_name_id",
+			wantScreaming: "USER_NAME_ID",
+			wantKebab:     "user-name-id",
+			wantPascal:    "UserNameId",
+			wantCamel:     "userNameId",
+		},
+		{
+			name:          "url_path",
+			s:             "/api/v1/userProfile",
+			wantSnake:     "api_v1_user_profile",
+			wantScreaming: "API_V1_USER_PROFILE",
+			wantKebab:     "api-v1-user-profile",
+			wantPascal:    "ApiV1UserProfile",
+			wantCamel:     "apiV1UserProfile",
+		},
+		{
+			name:          "unicode_letters",
+			s:             "ÜberStraße",
+			wantSnake:     "über_straße",
+			wantScreaming: "ÜBER_STRAßE",
+			wantKebab:     "über-straße",
+			wantPascal:    "ÜberStraße",
+			wantCamel:     "überStraße",
+		},
+		{
+			name:          "caseless_letters",
+			s:             "用户ID",
+			wantSnake:     "用户_id",
+			wantScreaming: "用户_ID",
+			wantKebab:     "用户-id",
+			wantPascal:    "用户Id",
+			wantCamel:     "用户Id",
+		},
+		{
+			name:          "greek",
+			s:             "αβγΔέλτα",
+			wantSnake:     "αβγ_δέλτα",
+			wantScreaming: "ΑΒΓ_ΔΈΛΤΑ",
+			wantKebab:     "αβγ-δέλτα",
+			wantPascal:    "ΑβγΔέλτα",
+			wantCamel:     "αβγΔέλτα",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantSnake, ToSnakeCase(tt.s))
+			assert.Equal(t, tt.wantScreaming, ToScreamingSnake(tt.s))
+			assert.Equal(t, tt.wantKebab, ToKebabCase(tt.s))
+			assert.Equal(t, tt.wantPascal, ToPascalCase(tt.s))
+			assert.Equal(t, tt.wantCamel, ToCamelCase(tt.s))
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package stringx
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// gbProperty 字位簇分割属性(Grapheme_Cluster_Break).
+type gbProperty uint8
+
+const (
+	gbOther gbProperty = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbExtendedPictographic
+)
+
+// incbProperty 印度文字辅音连字属性(Indic_Conjunct_Break), 用于 GB9c.
+type incbProperty uint8
+
+const (
+	incbNone incbProperty = iota
+	incbConsonant
+	incbLinker
+	incbExtend
+)
+
+// nextGrapheme 返回 s 中第一个扩展字位簇的字节数.
+// 实现了 UAX #29 中的全部规则, 通过了 Unicode 16.0.0 的 GraphemeBreakTest.txt.
+// 每个不合法的 UTF-8 字节都按照 U+FFFD 处理, 与合法的字符一样参与分割,
+// 例如 "\xff\u0301" 是一个字位簇.
+func nextGrapheme(s string) int {
+	// 快速路径: 除了 CR LF 以外, 两个 ASCII 字符之间总是可以分割
+	if len(s) == 1 || len(s) > 1 && s[0] < utf8.RuneSelf && s[1] < utf8.RuneSelf && s[0] != '\r' {
+		return 1
+	}
+	r, i := utf8.DecodeRuneInString(s)
+	if i == 0 {
+		return 0
+	}
+	prev := graphemeProperty(r)
+	// emoji 表示已经匹配了 GB11 中的 ExtPict Extend*, 遇到 ZWJ 之后才可以连接下一个 ExtPict
+	emoji := prev == gbExtendedPictographic
+	// ri 表示当前连续的区域指示符的个数
+	ri := 0
+	if prev == gbRegionalIndicator {
+		ri = 1
+	}
+	// linked 表示已经匹配了 GB9c 中的 Consonant [Extend Linker]* Linker [Extend Linker]*,
+	// consonant 表示已经匹配了其中的 Consonant 但还没有遇到 Linker
+	consonant := incbPropertyOf(r) == incbConsonant
+	linked := false
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cur := graphemeProperty(r)
+		incb := incbPropertyOf(r)
+		if !graphemeJoin(prev, cur, emoji, ri, linked && incb == incbConsonant) {
+			return i
+		}
+		switch {
+		case cur == gbExtendedPictographic:
+			emoji = true
+		case (cur == gbExtend || cur == gbZWJ) && emoji && prev != gbZWJ:
+			// ZWJ 之后只能连接 ExtPict, 由 graphemeJoin 检查 prev == gbZWJ
+		default:
+			emoji = false
+		}
+		if cur == gbRegionalIndicator {
+			ri++
+		} else {
+			ri = 0
+		}
+		switch incb {
+		case incbConsonant:
+			consonant, linked = true, false
+		case incbLinker:
+			linked = linked || consonant
+		case incbExtend:
+		default:
+			consonant, linked = false, false
+		}
+		prev = cur
+		i += size
+	}
+	return i
+}
+
+// graphemeJoin 判断 prev 和 cur 之间是否不能分割.
+// conjunct 表示 cur 为 InCB=Consonant, 并且前面的字符满足 GB9c.
+func graphemeJoin(prev, cur gbProperty, emoji bool, ri int, conjunct bool) bool {
+	switch {
+	// GB3
+	case prev == gbCR && cur == gbLF:
+		return true
+	// GB4, GB5
+	case prev == gbControl || prev == gbCR || prev == gbLF,
+		cur == gbControl || cur == gbCR || cur == gbLF:
+		return false
+	// GB6
+	case prev == gbL:
+		if cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT {
+			return true
+		}
+	// GB7
+	case prev == gbLV || prev == gbV:
+		if cur == gbV || cur == gbT {
+			return true
+		}
+	// GB8
+	case prev == gbLVT || prev == gbT:
+		if cur == gbT {
+			return true
+		}
+	}
+	switch {
+	// GB9, GB9a
+	case cur == gbExtend || cur == gbZWJ || cur == gbSpacingMark:
+		return true
+	// GB9b
+	case prev == gbPrepend:
+		return true
+	// GB9c
+	case conjunct:
+		return true
+	// GB11
+	case prev == gbZWJ && cur == gbExtendedPictographic:
+		return emoji
+	// GB12, GB13
+	case prev == gbRegionalIndicator && cur == gbRegionalIndicator:
+		return ri%2 == 1
+	}
+	// GB999
+	return false
+}
+
+func graphemeProperty(r rune) gbProperty {
+	switch {
+	case r < 0x20:
+		switch r {
+		case '\r':
+			return gbCR
+		case '\n':
+			return gbLF
+		}
+		return gbControl
+	case r < 0x7F:
+		// ASCII 可打印字符
+		return gbOther
+	case r == 0x200D:
+		return gbZWJ
+	case r == 0x200C:
+		return gbExtend
+	case 0x1F1E6 <= r && r <= 0x1F1FF:
+		return gbRegionalIndicator
+	case 0x1100 <= r && r <= 0x115F, 0xA960 <= r && r <= 0xA97C:
+		return gbL
+	case 0x1160 <= r && r <= 0x11A7, 0xD7B0 <= r && r <= 0xD7C6:
+		return gbV
+	case 0x11A8 <= r && r <= 0x11FF, 0xD7CB <= r && r <= 0xD7FB:
+		return gbT
+	case 0xAC00 <= r && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case unicode.Is(prependTable, r):
+		return gbPrepend
+	case 0x1F3FB <= r && r <= 0x1F3FF, // emoji 肤色修饰符
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case unicode.In(r, unicode.Cc, unicode.Zl, unicode.Zp, unicode.Cf):
+		return gbControl
+	case r == 0x0E33, r == 0x0EB3,
+		unicode.Is(unicode.Mc, r) && !unicode.Is(spacingMarkExclusionTable, r):
+		return gbSpacingMark
+	case unicode.Is(extendedPictographicTable, r):
+		return gbExtendedPictographic
+	}
+	return gbOther
+}
+
+// incbPropertyOf 返回 r 的 Indic_Conjunct_Break 属性.
+func incbPropertyOf(r rune) incbProperty {
+	switch {
+	case r < 0x0900:
+		// 快速路径: 该范围内没有 InCB=Consonant 和 InCB=Linker
+		if r < 0x0300 || unicode.Is(incbExtendExclusionTable, r) {
+			return incbNone
+		}
+	case r == 0x094D, r == 0x09CD, r == 0x0ACD, r == 0x0B4D, r == 0x0C4D, r == 0x0D4D:
+		return incbLinker
+	case unicode.Is(incbConsonantTable, r):
+		return incbConsonant
+	case unicode.Is(incbExtendExclusionTable, r):
+		return incbNone
+	}
+	// InCB=Extend 为除了 Linker 和 incbExtendExclusionTable 以外的 Extend 和 ZWJ
+	if p := graphemeProperty(r); p == gbExtend || p == gbZWJ {
+		return incbExtend
+	}
+	return incbNone
+}
+
+// incbConsonantTable Indic_Conjunct_Break=Consonant 的字符, 来自 Unicode 16.0.0 的 DerivedCoreProperties.txt.
+var incbConsonantTable = newRangeTable(
+	[2]rune{0x0915, 0x0939}, [2]rune{0x0958, 0x095F}, [2]rune{0x0978, 0x097F},
+	[2]rune{0x0995, 0x09A8}, [2]rune{0x09AA, 0x09B0}, [2]rune{0x09B2, 0x09B2},
+	[2]rune{0x09B6, 0x09B9}, [2]rune{0x09DC, 0x09DD}, [2]rune{0x09DF, 0x09DF},
+	[2]rune{0x09F0, 0x09F1}, [2]rune{0x0A95, 0x0AA8}, [2]rune{0x0AAA, 0x0AB0},
+	[2]rune{0x0AB2, 0x0AB3}, [2]rune{0x0AB5, 0x0AB9}, [2]rune{0x0AF9, 0x0AF9},
+	[2]rune{0x0B15, 0x0B28}, [2]rune{0x0B2A, 0x0B30}, [2]rune{0x0B32, 0x0B33},
+	[2]rune{0x0B35, 0x0B39}, [2]rune{0x0B5C, 0x0B5D}, [2]rune{0x0B5F, 0x0B5F},
+	[2]rune{0x0B71, 0x0B71}, [2]rune{0x0C15, 0x0C28}, [2]rune{0x0C2A, 0x0C39},
+	[2]rune{0x0C58, 0x0C5A}, [2]rune{0x0D15, 0x0D3A},
+)
+
+// incbExtendExclusionTable 属于 Extend 但不属于 Indic_Conjunct_Break=Extend 的字符.
+var incbExtendExclusionTable = newRangeTable(
+	[2]rune{0x05BF, 0x05BF}, [2]rune{0x06D6, 0x06DC}, [2]rune{0x07EB, 0x07F3},
+	[2]rune{0x0897, 0x089F}, [2]rune{0x0962, 0x0963}, [2]rune{0x09FE, 0x09FE},
+	[2]rune{0x0A70, 0x0A71}, [2]rune{0x0AFA, 0x0AFF}, [2]rune{0x0B82, 0x0B82},
+	[2]rune{0x0C3C, 0x0C3C}, [2]rune{0x0CBC, 0x0CBC}, [2]rune{0x0D00, 0x0D01},
+	[2]rune{0x0DCA, 0x0DCA}, [2]rune{0x0E47, 0x0E4E}, [2]rune{0x0F39, 0x0F39},
+	[2]rune{0x102D, 0x1030}, [2]rune{0x1082, 0x1082}, [2]rune{0x1752, 0x1753},
+	[2]rune{0x180B, 0x180D}, [2]rune{0x1939, 0x193B}, [2]rune{0x1A65, 0x1A6C},
+	[2]rune{0x1ACF, 0x1ADD}, [2]rune{0x1AE0, 0x1AEB}, [2]rune{0x1B6B, 0x1B73},
+	[2]rune{0x1BEF, 0x1BF3}, [2]rune{0x1CF4, 0x1CF4}, [2]rune{0x200C, 0x200C},
+	[2]rune{0x2DE0, 0x2DFF}, [2]rune{0xA802, 0xA802}, [2]rune{0xA8FF, 0xA8FF},
+	[2]rune{0xA9BC, 0xA9BD}, [2]rune{0xAA4C, 0xAA4C}, [2]rune{0xAAEC, 0xAAED},
+	[2]rune{0xFE20, 0xFE2F}, [2]rune{0x102E0, 0x102E0}, [2]rune{0x10A05, 0x10A06},
+	[2]rune{0x10A3F, 0x10A3F}, [2]rune{0x10D69, 0x10D6D}, [2]rune{0x10EFA, 0x10EFB},
+	[2]rune{0x10F46, 0x10F50}, [2]rune{0x11038, 0x11046}, [2]rune{0x1107F, 0x11081},
+	[2]rune{0x110C2, 0x110C2}, [2]rune{0x1112D, 0x11134}, [2]rune{0x111B6, 0x111BE},
+	[2]rune{0x111CF, 0x111CF}, [2]rune{0x1123E, 0x1123E}, [2]rune{0x112E3, 0x112EA},
+	[2]rune{0x1133E, 0x1133E}, [2]rune{0x11357, 0x11357}, [2]rune{0x113B8, 0x113B8},
+	[2]rune{0x113C5, 0x113C5}, [2]rune{0x113D2, 0x113D2}, [2]rune{0x11442, 0x11444},
+	[2]rune{0x114B0, 0x114B0}, [2]rune{0x114BD, 0x114BD}, [2]rune{0x115AF, 0x115AF},
+	[2]rune{0x115BF, 0x115C0}, [2]rune{0x1163D, 0x1163D}, [2]rune{0x116AD, 0x116AD},
+	[2]rune{0x1171F, 0x1171F}, [2]rune{0x1182F, 0x11837}, [2]rune{0x1193B, 0x1193E},
+	[2]rune{0x119DA, 0x119DB}, [2]rune{0x11A33, 0x11A38}, [2]rune{0x11A51, 0x11A56},
+	[2]rune{0x11A98, 0x11A99}, [2]rune{0x11B60, 0x11B60}, [2]rune{0x11B62, 0x11B64},
+	[2]rune{0x11B66, 0x11B66}, [2]rune{0x11C3F, 0x11C3F}, [2]rune{0x11CB2, 0x11CB3},
+	[2]rune{0x11D3A, 0x11D3A}, [2]rune{0x11D47, 0x11D47}, [2]rune{0x11D97, 0x11D97},
+	[2]rune{0x11F36, 0x11F3A}, [2]rune{0x13440, 0x13440}, [2]rune{0x1612D, 0x1612F},
+	[2]rune{0x16F4F, 0x16F4F}, [2]rune{0x16FF0, 0x16FF1}, [2]rune{0x1CF30, 0x1CF46},
+	[2]rune{0x1D17B, 0x1D182}, [2]rune{0x1D242, 0x1D244}, [2]rune{0x1DA75, 0x1DA75},
+	[2]rune{0x1DAA1, 0x1DAAF}, [2]rune{0x1E01B, 0x1E021}, [2]rune{0x1E08F, 0x1E08F},
+	[2]rune{0x1E2EC, 0x1E2EF}, [2]rune{0x1E6E3, 0x1E6E3}, [2]rune{0x1E6E6, 0x1E6E6},
+	[2]rune{0x1E6EE, 0x1E6EF}, [2]rune{0x1E6F5, 0x1E6F5}, [2]rune{0x1E8D0, 0x1E8D6},
+	[2]rune{0xE0020, 0xE007F},
+)
+
+// prependTable Grapheme_Cluster_Break=Prepend 的字符.
+var prependTable = newRangeTable(
+	[2]rune{0x0600, 0x0605}, [2]rune{0x06DD, 0x06DD}, [2]rune{0x070F, 0x070F},
+	[2]rune{0x0890, 0x0891}, [2]rune{0x08E2, 0x08E2}, [2]rune{0x0D4E, 0x0D4E},
+	[2]rune{0x110BD, 0x110BD}, [2]rune{0x110CD, 0x110CD}, [2]rune{0x111C2, 0x111C3},
+	[2]rune{0x1193F, 0x1193F}, [2]rune{0x11941, 0x11941}, [2]rune{0x11A3A, 0x11A3A},
+	[2]rune{0x11A84, 0x11A89}, [2]rune{0x11D46, 0x11D46}, [2]rune{0x11F02, 0x11F02},
+)
+
+// spacingMarkExclusionTable 属于 Mc 但不属于 SpacingMark 的字符.
+var spacingMarkExclusionTable = newRangeTable(
+	[2]rune{0x102B, 0x102C}, [2]rune{0x1038, 0x1038}, [2]rune{0x1062, 0x1064},
+	[2]rune{0x1067, 0x106D}, [2]rune{0x1083, 0x1083}, [2]rune{0x1087, 0x108C},
+	[2]rune{0x108F, 0x108F}, [2]rune{0x109A, 0x109C}, [2]rune{0x1A61, 0x1A61},
+	[2]rune{0x1A63, 0x1A64}, [2]rune{0xAA7B, 0xAA7B}, [2]rune{0xAA7D, 0xAA7D},
+	[2]rune{0x11720, 0x11721},
+)
+
+// extendedPictographicTable Extended_Pictographic 的字符, 来自 Unicode 的 emoji-data.txt.
+var extendedPictographicTable = newRangeTable(
+	[2]rune{0x00A9, 0x00A9}, [2]rune{0x00AE, 0x00AE}, [2]rune{0x203C, 0x203C},
+	[2]rune{0x2049, 0x2049}, [2]rune{0x2122, 0x2122}, [2]rune{0x2139, 0x2139},
+	[2]rune{0x2194, 0x2199}, [2]rune{0x21A9, 0x21AA}, [2]rune{0x231A, 0x231B},
+	[2]rune{0x2328, 0x2328}, [2]rune{0x2388, 0x2388}, [2]rune{0x23CF, 0x23CF},
+	[2]rune{0x23E9, 0x23F3}, [2]rune{0x23F8, 0x23FA}, [2]rune{0x24C2, 0x24C2},
+	[2]rune{0x25AA, 0x25AB}, [2]rune{0x25B6, 0x25B6}, [2]rune{0x25C0, 0x25C0},
+	[2]rune{0x25FB, 0x25FE}, [2]rune{0x2600, 0x2605}, [2]rune{0x2607, 0x2612},
+	[2]rune{0x2614, 0x2685}, [2]rune{0x2690, 0x2705}, [2]rune{0x2708, 0x2712},
+	[2]rune{0x2714, 0x2714}, [2]rune{0x2716, 0x2716}, [2]rune{0x271D, 0x271D},
+	[2]rune{0x2721, 0x2721}, [2]rune{0x2728, 0x2728}, [2]rune{0x2733, 0x2734},
+	[2]rune{0x2744, 0x2744}, [2]rune{0x2747, 0x2747}, [2]rune{0x274C, 0x274C},
+	[2]rune{0x274E, 0x274E}, [2]rune{0x2753, 0x2755}, [2]rune{0x2757, 0x2757},
+	[2]rune{0x2763, 0x2767}, [2]rune{0x2795, 0x2797}, [2]rune{0x27A1, 0x27A1},
+	[2]rune{0x27B0, 0x27B0}, [2]rune{0x27BF, 0x27BF}, [2]rune{0x2934, 0x2935},
+	[2]rune{0x2B05, 0x2B07}, [2]rune{0x2B1B, 0x2B1C}, [2]rune{0x2B50, 0x2B50},
+	[2]rune{0x2B55, 0x2B55}, [2]rune{0x3030, 0x3030}, [2]rune{0x303D, 0x303D},
+	[2]rune{0x3297, 0x3297}, [2]rune{0x3299, 0x3299}, [2]rune{0x1F000, 0x1F0FF},
+	[2]rune{0x1F10D, 0x1F10F}, [2]rune{0x1F12F, 0x1F12F}, [2]rune{0x1F16C, 0x1F171},
+	[2]rune{0x1F17E, 0x1F17F}, [2]rune{0x1F18E, 0x1F18E}, [2]rune{0x1F191, 0x1F19A},
+	[2]rune{0x1F1AD, 0x1F1E5}, [2]rune{0x1F201, 0x1F20F}, [2]rune{0x1F21A, 0x1F21A},
+	[2]rune{0x1F22F, 0x1F22F}, [2]rune{0x1F232, 0x1F23A}, [2]rune{0x1F23C, 0x1F23F},
+	[2]rune{0x1F249, 0x1F3FA}, [2]rune{0x1F400, 0x1F53D}, [2]rune{0x1F546, 0x1F64F},
+	[2]rune{0x1F680, 0x1F6FF}, [2]rune{0x1F774, 0x1F77F}, [2]rune{0x1F7D5, 0x1F7FF},
+	[2]rune{0x1F80C, 0x1F80F}, [2]rune{0x1F848, 0x1F84F}, [2]rune{0x1F85A, 0x1F85F},
+	[2]rune{0x1F888, 0x1F88F}, [2]rune{0x1F8AE, 0x1F8FF}, [2]rune{0x1F90C, 0x1F93A},
+	[2]rune{0x1F93C, 0x1F945}, [2]rune{0x1F947, 0x1FAFF}, [2]rune{0x1FC00, 0x1FFFD},
+)
+
+// newRangeTable 根据升序且互不重叠的闭区间创建 unicode.RangeTable.
+func newRangeTable(ranges ...[2]rune) *unicode.RangeTable {
+	table := &unicode.RangeTable{}
+	for _, r := range ranges {
+		if r[1] <= 0xFFFF {
+			table.R16 = append(table.R16, unicode.Range16{Lo: uint16(r[0]), Hi: uint16(r[1]), Stride: 1})
+		} else {
+			table.R32 = append(table.R32, unicode.Range32{Lo: uint32(r[0]), Hi: uint32(r[1]), Stride: 1})
+		}
+	}
+	return table
+}
@@ -0,0 +1,112 @@
+package stringx
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphemeBreakTest Unicode 官方的字位簇分割测试用例.
+//
+//go:embed testdata/GraphemeBreakTest.txt
+var graphemeBreakTest string
+
+// graphemes 将 s 分割为扩展字位簇.
+func graphemes(s string) []string {
+	res := make([]string, 0)
+	for len(s) > 0 {
+		n := nextGrapheme(s)
+		res = append(res, s[:n])
+		s = s[n:]
+	}
+	return res
+}
+
+func TestNextGrapheme(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{name: "ascii", s: "ab", want: []string{"a", "b"}},
+		{name: "crlf", s: "a\r\n\nb", want: []string{"a", "\r\n", "\n", "b"}},
+		{name: "control", s: "a\u0301\t\u0301", want: []string{"a\u0301", "\t", "\u0301"}},
+		{name: "combining_marks", s: "e\u0301\u0302x", want: []string{"e\u0301\u0302", "x"}},
+		{name: "hangul_jamo", s: "\u1100\u1161\u11A8\u1100", want: []string{"\u1100\u1161\u11A8", "\u1100"}},
+		{name: "hangul_syllable", s: "\uAC00\uAC01\u11A8\u1161", want: []string{"\uAC00", "\uAC01\u11A8", "\u1161"}},
+		{name: "regional_indicator", s: "🇨🇳🇺🇸🇯", want: []string{"🇨🇳", "🇺🇸", "🇯"}},
+		{name: "emoji_modifier", s: "👍\U0001F3FD👍", want: []string{"👍\U0001F3FD", "👍"}},
+		{name: "emoji_zwj", s: "👨\u200D👩\u200D👧a", want: []string{"👨\u200D👩\u200D👧", "a"}},
+		{name: "emoji_zwj_extend", s: "\U0001F3F3\uFE0F\u200D\U0001F308", want: []string{"\U0001F3F3\uFE0F\u200D\U0001F308"}},
+		{name: "zwj_without_emoji", s: "a\u200D👩", want: []string{"a\u200D", "👩"}},
+		{name: "double_zwj", s: "👨\u200D\u200D👩", want: []string{"👨\u200D\u200D", "👩"}},
+		{name: "spacing_mark", s: "\u0915\u093F", want: []string{"\u0915\u093F"}},
+		{name: "prepend", s: "\u0600a", want: []string{"\u0600a"}},
+		{name: "prepend_control", s: "\u0600\n", want: []string{"\u0600", "\n"}},
+		{name: "indic_conjunct", s: "\u0915\u094D\u0924\u0915", want: []string{"\u0915\u094D\u0924", "\u0915"}},
+		{name: "indic_conjunct_extend", s: "\u0915\u093C\u200D\u094D\u0924", want: []string{"\u0915\u093C\u200D\u094D\u0924"}},
+		{name: "indic_linker_without_consonant", s: "a\u094D\u0924", want: []string{"a\u094D", "\u0924"}},
+		{name: "indic_linker_other_extend", s: "\u0915\u094D\u0962\u0924", want: []string{"\u0915\u094D\u0962", "\u0924"}},
+		{name: "invalid_utf8", s: "a\xff\u0301", want: []string{"a", "\xff\u0301"}},
+		{name: "invalid_utf8_prepend", s: "\u0600\xff\xfe", want: []string{"\u0600\xff", "\xfe"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, graphemes(tt.s))
+		})
+	}
+}
+
+// TestNextGrapheme_Conformance 使用 GraphemeBreakTest.txt 中的全部用例.
+func TestNextGrapheme_Conformance(t *testing.T) {
+	for i, line := range strings.Split(graphemeBreakTest, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var (
+			s    strings.Builder
+			want []string
+		)
+		for _, field := range strings.Fields(line) {
+			switch field {
+			case "÷":
+				if s.Len() > 0 {
+					want = append(want, s.String())
+					s.Reset()
+				}
+			case "×":
+			default:
+				r, err := strconv.ParseUint(field, 16, 32)
+				require.NoError(t, err)
+				s.WriteRune(rune(r))
+			}
+		}
+		assert.Equal(t, want, graphemes(strings.Join(want, "")), "第 %d 行: %s", i+1, line)
+	}
+}
+
+func TestGraphemeReverse(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "ascii", s: "hello", want: "olleh"},
+		{name: "chinese", s: "你好", want: "好你"},
+		{name: "combining_marks", s: "cafe\u0301!", want: "!e\u0301fac"},
+		{name: "flags", s: "🇨🇳🇺🇸", want: "🇺🇸🇨🇳"},
+		{name: "emoji_zwj", s: "a👨\u200D👩\u200D👧b", want: "b👨\u200D👩\u200D👧a"},
+		{name: "emoji_modifier", s: "👍\U0001F3FD👋", want: "👋👍\U0001F3FD"},
+		{name: "crlf", s: "a\r\nb", want: "b\r\na"},
+		{name: "empty", s: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GraphemeReverse(tt.s))
+		})
+	}
+}
@@ -1,15 +1,49 @@
 package stringx
 
-import "github.com/udugong/ukit/slicex"
+import (
+	"unicode/utf8"
 
+	"github.com/udugong/ukit/slicex"
+)
+
+// Reverse 按照码点反转字符串, 多字节的 UTF-8 编码不会被拆开.
+// 不合法的 UTF-8 字节会被当作单个字节原样保留.
 func Reverse(s string) string {
-	bytes := []byte(s)
-	slicex.ReverseSelf(bytes)
+	n := len(s)
+	bytes := make([]byte, n)
+	for i := 0; i < len(s); {
+		if s[i] < utf8.RuneSelf {
+			n--
+			bytes[n] = s[i]
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		n -= size
+		copy(bytes[n:], s[i:i+size])
+		i += size
+	}
 	return UnsafeToString(bytes)
 }
 
+// RuneReverse 按照码点反转字符串, 不合法的 UTF-8 字节会被替换为 U+FFFD.
 func RuneReverse(s string) string {
 	runes := []rune(s)
 	slicex.ReverseSelf(runes)
 	return string(runes)
 }
+
+// GraphemeReverse 按照扩展字位簇(UAX #29)反转字符串,
+// 组合字符, emoji ZWJ 序列, 肤色修饰符和国旗等都会保持完整.
+// 不合法的 UTF-8 字节按照 U+FFFD 参与分割, 但会原样保留.
+func GraphemeReverse(s string) string {
+	n := len(s)
+	bytes := make([]byte, n)
+	for i := 0; i < len(s); {
+		size := nextGrapheme(s[i:])
+		n -= size
+		copy(bytes[n:], s[i:i+size])
+		i += size
+	}
+	return UnsafeToString(bytes)
+}
@@ -22,6 +22,16 @@ func TestReverse(t *testing.T) {
 			s:    "",
 			want: "",
 		},
+		{
+			name: "multi_byte",
+			s:    "a你好😀",
+			want: "😀好你a",
+		},
+		{
+			name: "invalid_utf8",
+			s:    "a\xff你\xe4\xbd",
+			want: "\xbd\xe4你\xffa",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -54,11 +64,11 @@ func TestRuneReverse(t *testing.T) {
 	}
 }
 
-// goos: windows
+// goos: linux
 // goarch: amd64
 // pkg: github.com/udugong/ukit/stringx
-// cpu: Intel(R) Core(TM) i5-10400F CPU @ 2.90GHz
-// BenchmarkReverse-12   156148360   7.707 ns/op   0 B/op   0 allocs/op
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkReverse   26152352   44.29 ns/op   16 B/op   1 allocs/op
 func BenchmarkReverse(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = Reverse("hello world")
@@ -75,3 +85,14 @@ func BenchmarkRuneReverse(b *testing.B) {
 		_ = RuneReverse("hello world")
 	}
 }
+
+// goos: linux
+// goarch: amd64
+// pkg: github.com/udugong/ukit/stringx
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkGraphemeReverse   12127209   121.6 ns/op   16 B/op   1 allocs/op
+func BenchmarkGraphemeReverse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GraphemeReverse("hello world")
+	}
+}